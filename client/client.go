@@ -20,6 +20,7 @@ type API interface {
 	GetProviders() []providers.Provider
 	Instances() []*vpp.Instance
 	DiscoverInstances(queryParams ...map[string]string) error
+	RediscoverInstances(queryParams ...map[string]string) ([]*vpp.Instance, error)
 	Close() error
 }
 
@@ -96,6 +97,35 @@ func (c *Client) AddProvider(provider providers.Provider) error {
 // DiscoverInstances discovers running VPP instances via probe provider and
 // updates the list of instances with active instances from discovery.
 func (c *Client) DiscoverInstances(queryParams ...map[string]string) error {
+	return c.discoverInstances(nil, queryParams...)
+}
+
+// RediscoverInstances re-runs discovery, but keeps previously discovered
+// instances whose ID did not change instead of initializing them again.
+// Instances that were not discovered again are returned and the caller is
+// responsible for closing their handlers.
+func (c *Client) RediscoverInstances(queryParams ...map[string]string) ([]*vpp.Instance, error) {
+	existing := make(map[string]*vpp.Instance, len(c.instances))
+	for _, instance := range c.instances {
+		existing[instance.Handler().ID()] = instance
+	}
+
+	err := c.discoverInstances(existing, queryParams...)
+
+	current := make(map[*vpp.Instance]bool, len(c.instances))
+	for _, instance := range c.instances {
+		current[instance] = true
+	}
+	var removed []*vpp.Instance
+	for _, instance := range existing {
+		if !current[instance] {
+			removed = append(removed, instance)
+		}
+	}
+	return removed, err
+}
+
+func (c *Client) discoverInstances(existing map[string]*vpp.Instance, queryParams ...map[string]string) error {
 	if len(c.providers) == 0 {
 		return fmt.Errorf("no providers available")
 	}
@@ -111,7 +141,7 @@ func (c *Client) DiscoverInstances(queryParams ...map[string]string) error {
 
 	for _, p := range c.providers {
 		go func(provider providers.Provider) {
-			instances, err := DiscoverInstances(provider, queryParams...)
+			instances, err := discoverInstances(provider, existing, queryParams...)
 			if err != nil {
 				logrus.Warnf("provider %q discover error: %v", provider.Name(), err)
 			}
@@ -151,15 +181,31 @@ func (c *Client) DiscoverInstances(queryParams ...map[string]string) error {
 // DiscoverInstances discovers running VPP instances using provider and
 // returns the list of instances or error if provider query fails.
 func DiscoverInstances(provider providers.Provider, queryParams ...map[string]string) ([]*vpp.Instance, error) {
+	return discoverInstances(provider, nil, queryParams...)
+}
+
+// discoverInstances discovers instances using provider, instances found in
+// existing by handler ID are reused instead of initializing new ones.
+func discoverInstances(provider providers.Provider, existing map[string]*vpp.Instance, queryParams ...map[string]string) ([]*vpp.Instance, error) {
 	handlers, err := provider.Query(queryParams...)
 	if err != nil {
 		return nil, err
 	}
 
+	var instances []*vpp.Instance
 	var initInstances []*vpp.Instance
 	for _, handler := range handlers {
 		log := logrus.WithField("instance", handler.ID())
 
+		if inst, ok := existing[handler.ID()]; ok {
+			log.Debugf("reusing existing vpp instance")
+			if err := handler.Close(); err != nil {
+				log.Debugf("closing handler failed: %v", err)
+			}
+			instances = append(instances, inst)
+			continue
+		}
+
 		inst, err := vpp.NewInstance(handler)
 		if err != nil {
 			log.Debugf("vpp instance init failed: %v", err)
@@ -169,6 +215,10 @@ func DiscoverInstances(provider providers.Provider, queryParams ...map[string]st
 		initInstances = append(initInstances, inst)
 	}
 
+	if len(initInstances) == 0 && len(instances) > 0 {
+		return instances, nil
+	}
+
 	instch := make(chan *vpp.Instance, len(initInstances))
 
 	if err := RunOnInstances(initInstances, func(instance *vpp.Instance) error {
@@ -177,12 +227,11 @@ func DiscoverInstances(provider providers.Provider, queryParams ...map[string]st
 			instch <- instance
 		}
 		return err
-	}); err != nil {
+	}); err != nil && len(instances) == 0 {
 		return nil, err
 	}
 	close(instch)
 
-	var instances []*vpp.Instance
 	for inst := range instch {
 		instances = append(instances, inst)
 	}
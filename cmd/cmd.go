@@ -80,8 +80,20 @@ func NewRootCmd(cli Cli) *cobra.Command {
 		NewInspectorCmd(cli),
 		NewTopologyCmd(cli),
 		NewDiscoverCmd(cli),
+		NewDriftCmd(cli),
+		NewVerifyCmd(cli),
+		NewAgentCmd(cli),
 		NewTraceCmd(cli),
+		NewCountersCmd(cli),
+		NewApiTraceCmd(cli),
+		NewApiCmd(cli),
 		NewExecCmd(cli),
+		NewEventsCmd(cli),
+		NewRestartsCmd(cli),
+		NewStatsCmd(cli),
+		NewServeMetricsCmd(cli),
+		NewRecordCmd(cli),
+		NewReplayStatsCmd(cli),
 	)
 
 	cmd.InitDefaultHelpCmd()
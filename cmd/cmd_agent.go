@@ -0,0 +1,364 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"go.ligato.io/vpp-probe/client"
+	"go.ligato.io/vpp-probe/probe"
+	"go.ligato.io/vpp-probe/vpp"
+	"go.ligato.io/vpp-probe/vpp/agent"
+)
+
+func NewAgentCmd(cli Cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agent [command]",
+		Short: "Inspect VPP-Agent instances",
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(
+		NewAgentStatusCmd(cli),
+		NewAgentTxnsCmd(cli),
+	)
+	return cmd
+}
+
+const agentStatusExample = `  # Show status of agents in Kubernetes
+  vpp-probe agent status -e kube
+
+  # Wait until all agents are ready
+  until vpp-probe agent status -e kube; do sleep 5; done`
+
+type AgentStatusOptions struct {
+	ResyncSince time.Duration
+	Format      string
+}
+
+func NewAgentStatusCmd(cli Cli) *cobra.Command {
+	var (
+		opts = AgentStatusOptions{
+			ResyncSince: 24 * time.Hour,
+		}
+	)
+	cmd := &cobra.Command{
+		Use:     "status [options]",
+		Short:   "Show agent status",
+		Long:    "Show status of agents with plugin states, KV store connection and last resync. Exits with non-zero code if any agent is not ready, instances without agent are skipped.",
+		Example: agentStatusExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunAgentStatus(cli, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.DurationVar(&opts.ResyncSince, "resync-since", opts.ResyncSince, "Look for last resync in transactions newer than relative duration (all if zero)")
+	flags.StringVarP(&opts.Format, "format", "f", "", "Output format (json, yaml, go-template..)")
+	return cmd
+}
+
+// InstanceAgentStatus is agent status of an instance.
+type InstanceAgentStatus struct {
+	Instance string
+	Ready    bool
+	Error    string      `json:",omitempty"`
+	Info     *agent.Info `json:",omitempty"`
+
+	handler probe.Handler
+}
+
+func RunAgentStatus(cli Cli, opts AgentStatusOptions) error {
+	if err := cli.Client().DiscoverInstances(cli.Queries()...); err != nil {
+		return err
+	}
+	instances, skipped, err := agentInstances(cli.Client().Instances())
+	if err != nil {
+		return err
+	}
+
+	var since time.Time
+	if opts.ResyncSince > 0 {
+		since = time.Now().Add(-opts.ResyncSince)
+	}
+
+	statusch := make(chan *InstanceAgentStatus, len(instances))
+
+	if err := client.RunOnInstances(instances, func(instance *vpp.Instance) error {
+		status := &InstanceAgentStatus{
+			Instance: instance.ID(),
+			handler:  instance.Handler(),
+		}
+		defer func() { statusch <- status }()
+
+		info, err := agent.RetrieveInfo(instance.Agent().Client())
+		if err != nil {
+			status.Error = err.Error()
+			return nil
+		}
+		instance.Agent().Info = info
+		if err := instance.Agent().UpdateTxnHistory(since); err != nil {
+			logrus.Debugf("instance %v: %v", instance.ID(), err)
+		}
+		status.Info = info
+		status.Ready = info.IsReady()
+		return nil
+	}); err != nil {
+		return err
+	}
+	close(statusch)
+
+	var list []*InstanceAgentStatus
+	var notReady int
+	for s := range statusch {
+		list = append(list, s)
+		if !s.Ready {
+			notReady++
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Instance < list[j].Instance
+	})
+
+	if opts.Format != "" {
+		if err := formatAsTemplate(cli.Out(), opts.Format, list); err != nil {
+			return err
+		}
+	} else {
+		for _, s := range list {
+			printInstanceAgentStatus(cli.Out(), s)
+		}
+		printSkippedInstances(cli.Out(), skipped)
+	}
+
+	if notReady > 0 {
+		return fmt.Errorf("%d of %d agents not ready", notReady, len(list))
+	}
+	return nil
+}
+
+// agentInstances splits instances into those with agent and those without,
+// returning error if none of the instances has agent.
+func agentInstances(all []*vpp.Instance) (instances, skipped []*vpp.Instance, err error) {
+	for _, instance := range all {
+		if instance.Agent() == nil {
+			skipped = append(skipped, instance)
+			continue
+		}
+		instances = append(instances, instance)
+	}
+	if len(instances) == 0 {
+		return nil, nil, fmt.Errorf("agent not found in any of %d instances", len(skipped))
+	}
+	return instances, skipped, nil
+}
+
+func printSkippedInstances(out io.Writer, skipped []*vpp.Instance) {
+	for _, instance := range skipped {
+		fmt.Fprint(out, renderColor(colorize(nonAvailableColor, fmt.Sprintf("skipped %v: agent not found\n", instance.ID()))))
+	}
+}
+
+func printInstanceAgentStatus(out io.Writer, s *InstanceAgentStatus) {
+	var buf bytes.Buffer
+
+	printInstanceHeader(&buf, s.handler)
+
+	if s.Info == nil {
+		fmt.Fprintf(&buf, "%s\n\n", colorize(statusDownColor, s.Error))
+		fmt.Fprint(out, renderColor(buf.String()))
+		return
+	}
+
+	formatState := func(state string) string {
+		switch state {
+		case agent.StateOK:
+			return colorize(statusUpColor, state)
+		case "":
+			return colorize(nonAvailableColor, "N/A")
+		default:
+			return colorize(statusDownColor, state)
+		}
+	}
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return colorize(nonAvailableColor, "N/A")
+		}
+		return fmt.Sprintf("%s (%s ago)", t.Format(time.RFC3339), shortHumanDuration(time.Since(t)))
+	}
+
+	info := s.Info
+	fmt.Fprintf(&buf, "State:       %s\n", formatState(info.Status.State))
+	fmt.Fprintf(&buf, "Version:     %s %s\n", info.Status.BuildVersion, colorize(nonAvailableColor, info.Status.BuildDate))
+	fmt.Fprintf(&buf, "Started:     %s\n", formatTime(info.Status.StartTime))
+	fmt.Fprintf(&buf, "Transport:   %s\n", info.Transport)
+	if info.KVStore != nil {
+		fmt.Fprintf(&buf, "KV store:    %s %s\n", info.KVStore.Name, formatState(info.KVStore.State))
+	} else {
+		fmt.Fprintf(&buf, "KV store:    %s\n", colorize(nonAvailableColor, "N/A"))
+	}
+	fmt.Fprintf(&buf, "Last resync: %s\n", formatTime(info.LastResync))
+
+	if len(info.Plugins) > 0 {
+		fmt.Fprintln(&buf, "Plugins:")
+		w := tabwriter.NewWriter(&buf, 0, 1, 2, ' ', tabwriter.StripEscape|tabwriter.FilterHTML)
+		for _, p := range info.Plugins {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t\n", p.Name, formatState(p.State), colorize(statusDownColor, p.Error))
+		}
+		if err := w.Flush(); err != nil {
+			logrus.Warnf("flushing table failed: %v", err)
+		}
+	}
+	fmt.Fprintln(&buf)
+
+	fmt.Fprint(out, renderColor(buf.String()))
+}
+
+const agentTxnsExample = `  # List agent transactions from last 10 minutes in Kubernetes
+  vpp-probe agent txns -e kube
+
+  # List agent transactions from last hour in JSON
+  vpp-probe agent txns --since 1h -f json`
+
+type AgentTxnsOptions struct {
+	Since  time.Duration
+	Format string
+}
+
+func NewAgentTxnsCmd(cli Cli) *cobra.Command {
+	var (
+		opts = AgentTxnsOptions{
+			Since: 10 * time.Minute,
+		}
+	)
+	cmd := &cobra.Command{
+		Use:     "txns [options]",
+		Short:   "List agent transactions",
+		Long:    "List kvscheduler transactions of agents across instances with their operations",
+		Example: agentTxnsExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunAgentTxns(cli, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.DurationVar(&opts.Since, "since", opts.Since, "Show transactions newer than relative duration (all if zero)")
+	flags.StringVarP(&opts.Format, "format", "f", "", "Output format (json, yaml, go-template..)")
+	return cmd
+}
+
+// InstanceTxns is transaction history of an instance.
+type InstanceTxns struct {
+	Instance string
+	Txns     []agent.Txn
+
+	handler probe.Handler
+}
+
+func RunAgentTxns(cli Cli, opts AgentTxnsOptions) error {
+	if err := cli.Client().DiscoverInstances(cli.Queries()...); err != nil {
+		return err
+	}
+	instances, skipped, err := agentInstances(cli.Client().Instances())
+	if err != nil {
+		return err
+	}
+
+	var since time.Time
+	if opts.Since > 0 {
+		since = time.Now().Add(-opts.Since)
+	}
+
+	txnch := make(chan *InstanceTxns, len(instances))
+
+	if err := client.RunOnInstances(instances, func(instance *vpp.Instance) error {
+		if err := instance.Agent().UpdateTxnHistory(since); err != nil {
+			return fmt.Errorf("instance %v error: %w", instance.ID(), err)
+		}
+		txnch <- &InstanceTxns{
+			Instance: instance.ID(),
+			Txns:     instance.Agent().Txns,
+			handler:  instance.Handler(),
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	close(txnch)
+
+	var list []*InstanceTxns
+	for t := range txnch {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Instance < list[j].Instance
+	})
+
+	if opts.Format != "" {
+		return formatAsTemplate(cli.Out(), opts.Format, list)
+	}
+	for _, t := range list {
+		printInstanceTxns(cli.Out(), t)
+	}
+	printSkippedInstances(cli.Out(), skipped)
+	return nil
+}
+
+func printInstanceTxns(out io.Writer, t *InstanceTxns) {
+	var buf bytes.Buffer
+
+	printInstanceHeader(&buf, t.handler)
+
+	if len(t.Txns) == 0 {
+		fmt.Fprintf(&buf, "%s\n\n", colorize(nonAvailableColor, "no transactions"))
+		fmt.Fprint(out, renderColor(buf.String()))
+		return
+	}
+
+	for _, txn := range t.Txns {
+		typ := txn.Type
+		if txn.Resync != "" {
+			typ += " " + txn.Resync + " resync"
+		}
+		fmt.Fprintf(&buf, "#%d %s %s %s",
+			txn.SeqNum,
+			colorize(highlightColor, typ),
+			colorize(nonAvailableColor, txn.Start.Format("15:04:05")),
+			colorize(nonAvailableColor, fmt.Sprintf("(%v, %d values)", txn.Stop.Sub(txn.Start).Round(time.Millisecond), txn.Values)),
+		)
+		if len(txn.Errors) > 0 {
+			fmt.Fprintf(&buf, " %s", colorize(statusDownColor, fmt.Sprintf("%d errors", len(txn.Errors))))
+		}
+		if txn.Description != "" {
+			fmt.Fprintf(&buf, " - %s", txn.Description)
+		}
+		fmt.Fprintln(&buf)
+
+		w := tabwriter.NewWriter(&buf, 0, 1, 2, ' ', tabwriter.StripEscape|tabwriter.FilterHTML)
+		for _, op := range txn.Ops {
+			var flags string
+			if op.IsDerived {
+				flags += " derived"
+			}
+			if op.IsRetry {
+				flags += " retry"
+			}
+			state := op.NewState
+			if op.Error != "" {
+				state = colorize(statusDownColor, state+": "+op.Error)
+			}
+			fmt.Fprintf(w, "    %s\t%s\t%s\t%s\t\n", op.Operation, colorize(valueColor, op.Key), state, colorize(nonAvailableColor, flags))
+		}
+		if err := w.Flush(); err != nil {
+			logrus.Warnf("flushing table failed: %v", err)
+		}
+	}
+	fmt.Fprintln(&buf)
+
+	fmt.Fprint(out, renderColor(buf.String()))
+}
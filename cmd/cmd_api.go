@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	govppapi "go.fd.io/govpp/api"
+
+	"go.ligato.io/vpp-probe/pkg/strutil"
+	"go.ligato.io/vpp-probe/vpp"
+)
+
+const apiExample = `  # Send request to VPP instances in Kubernetes
+  vpp-probe api -e kube show_version
+
+  # Send dump request with data
+  vpp-probe api -e kube sw_interface_rx_placement_dump --data '{"sw_if_index": 1}'
+
+  # List available messages containing 'placement'
+  vpp-probe api -e kube --list placement`
+
+type ApiOptions struct {
+	Message string
+	Data    string
+	List    bool
+	Format  string
+}
+
+func NewApiCmd(cli Cli) *cobra.Command {
+	var (
+		opts ApiOptions
+	)
+	cmd := &cobra.Command{
+		Use:     "api [options] MESSAGE",
+		Short:   "Send binary API request to VPP instances",
+		Long:    "Send binary API request message by name to VPP instances and print replies",
+		Example: apiExample,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Message = args[0]
+			}
+			if !opts.List && opts.Message == "" {
+				return fmt.Errorf("message name required")
+			}
+			return RunApi(cli, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opts.Data, "data", "", "Request message data in JSON format")
+	flags.BoolVar(&opts.List, "list", false, "List available messages (optionally containing MESSAGE)")
+	flags.StringVarP(&opts.Format, "format", "f", "", "Output format (json, yaml, go-template..)")
+	return cmd
+}
+
+// ApiResult is a result of binary API request for an instance.
+type ApiResult struct {
+	Instance string
+	Replies  []govppapi.Message `json:",omitempty"`
+	Error    string             `json:",omitempty"`
+
+	instance *vpp.Instance
+}
+
+func RunApi(cli Cli, opts ApiOptions) error {
+	if err := cli.Client().DiscoverInstances(cli.Queries()...); err != nil {
+		return err
+	}
+	instances := cli.Client().Instances()
+
+	if opts.List {
+		return listApiMessages(cli.Out(), instances, opts.Message)
+	}
+
+	var results []*ApiResult
+	for _, instance := range instances {
+		result := &ApiResult{
+			Instance: instance.ID(),
+			instance: instance,
+		}
+		replies, err := instance.SendRequest(opts.Message, []byte(opts.Data))
+		if err != nil {
+			logrus.Warnf("sending request to instance %v failed: %v", instance.ID(), err)
+			result.Error = err.Error()
+		}
+		result.Replies = replies
+		results = append(results, result)
+	}
+
+	if opts.Format != "" {
+		return formatAsTemplate(cli.Out(), opts.Format, results)
+	}
+
+	for _, result := range results {
+		var buf bytes.Buffer
+
+		printInstanceHeader(&buf, result.instance.Handler())
+
+		w := strutil.IndentedWriter(&buf)
+		if result.Error != "" {
+			fmt.Fprintln(w, colorize(statusDownColor, result.Error))
+		} else if len(result.Replies) == 0 {
+			fmt.Fprintln(w, colorize(nonAvailableColor, "no replies"))
+		} else {
+			fmt.Fprint(w, yamlTmpl(result.Replies))
+		}
+
+		fmt.Fprint(cli.Out(), renderColor(buf.String()))
+	}
+
+	return nil
+}
+
+func listApiMessages(out io.Writer, instances []*vpp.Instance, search string) error {
+	msgs := map[string]govppapi.Message{}
+	for _, instance := range instances {
+		for _, msg := range instance.BinapiMessages() {
+			if search != "" && !strings.Contains(msg.GetMessageName(), search) {
+				continue
+			}
+			msgs[msg.GetMessageName()+"_"+msg.GetCrcString()] = msg
+		}
+	}
+	if len(msgs) == 0 {
+		return fmt.Errorf("no messages found")
+	}
+
+	keys := make([]string, 0, len(msgs))
+	for k := range msgs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "MESSAGE\tCRC\tTYPE\t")
+	for _, k := range keys {
+		msg := msgs[k]
+		fmt.Fprintf(w, "%s\t%s\t%s\t\n", msg.GetMessageName(), msg.GetCrcString(), apiMessageType(msg))
+	}
+	return w.Flush()
+}
+
+func apiMessageType(msg govppapi.Message) string {
+	switch msg.GetMessageType() {
+	case govppapi.RequestMessage:
+		return "request"
+	case govppapi.ReplyMessage:
+		return "reply"
+	case govppapi.EventMessage:
+		return "event"
+	default:
+		return "other"
+	}
+}
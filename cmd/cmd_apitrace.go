@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"go.ligato.io/vpp-probe/pkg/strutil"
+	"go.ligato.io/vpp-probe/probe"
+	"go.ligato.io/vpp-probe/vpp"
+	"go.ligato.io/vpp-probe/vpp/apitrace"
+)
+
+const apitraceExample = `  # Trace binary API messages for duration 10s
+  apitrace --env kube -- sleep 10
+
+  # Trace binary API messages while running a command and print as JSON
+  apitrace --env kube -f json -- vpp-agent-ctl config.yaml`
+
+type ApiTraceOptions struct {
+	CustomCmd string
+	Format    string
+}
+
+func NewApiTraceCmd(cli Cli) *cobra.Command {
+	var (
+		opts ApiTraceOptions
+	)
+	cmd := &cobra.Command{
+		Use:     "apitrace [flags] -- [command]",
+		Short:   "Trace binary API messages from VPP instances",
+		Long:    "Trace binary API messages from VPP instances while executing a command (defaults to 'sleep 5')",
+		Example: apitraceExample,
+		Args:    cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				opts.CustomCmd = "sleep 5"
+			} else {
+				opts.CustomCmd = strings.Join(args, " ")
+			}
+			return RunApiTrace(cli, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.Format, "format", "f", "", "Output format (json, yaml, go-template..)")
+	return cmd
+}
+
+// ApiTraceResult is a result of API trace for an instance.
+type ApiTraceResult struct {
+	Instance string
+	Wrapped  bool `json:",omitempty"`
+	Calls    []*apitrace.Call
+
+	handler probe.Handler
+}
+
+func RunApiTrace(cli Cli, opts ApiTraceOptions) error {
+	if err := cli.Client().DiscoverInstances(cli.Queries()...); err != nil {
+		return err
+	}
+	instances := cli.Client().Instances()
+
+	logrus.Debugf("running API trace with %d instances", len(instances))
+
+	var traced []*vpp.Instance
+	for _, instance := range instances {
+		if err := apitrace.Start(instance); err != nil {
+			logrus.Warnf("starting API trace for instance %v failed: %v", instance.ID(), err)
+			continue
+		}
+		traced = append(traced, instance)
+	}
+
+	if len(traced) == 0 {
+		return fmt.Errorf("failed to start API trace for instances")
+	}
+	logrus.Infof("API tracing started for %d/%d instances", len(traced), len(instances))
+
+	var commandErr error
+	cmd := exec.Command("sh", "-c", opts.CustomCmd)
+	cmd.Stderr = cli.Err()
+	cmd.Stdout = cli.Out()
+	logrus.Infof("running command: %v", cmd)
+
+	fmt.Fprintln(cli.Err())
+	if commandErr = cmd.Run(); commandErr != nil {
+		logrus.Warnf("command failed: %v", commandErr)
+	}
+	fmt.Fprintln(cli.Err())
+
+	name := fmt.Sprintf("vppprobe-apitrace-%d", time.Now().Unix())
+
+	var results []*ApiTraceResult
+	for _, instance := range traced {
+		result, err := retrieveApiTrace(instance, name)
+		if err != nil {
+			logrus.Warnf("retrieving API trace for instance %v failed: %v", instance.ID(), err)
+			continue
+		}
+		logrus.Debugf("API trace from instance %v contains %d calls", instance.ID(), len(result.Calls))
+		results = append(results, result)
+	}
+
+	if opts.Format != "" {
+		if err := formatAsTemplate(cli.Out(), opts.Format, results); err != nil {
+			return err
+		}
+		return commandErr
+	}
+
+	for _, result := range results {
+		var buf bytes.Buffer
+
+		printInstanceHeader(&buf, result.handler)
+		printApiTrace(strutil.IndentedWriter(&buf), result)
+
+		fmt.Fprint(cli.Out(), renderColor(buf.String()))
+	}
+
+	return commandErr
+}
+
+func retrieveApiTrace(instance *vpp.Instance, name string) (*ApiTraceResult, error) {
+	files, err := apitrace.Save(instance, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ApiTraceResult{
+		Instance: instance.ID(),
+		handler:  instance.Handler(),
+	}
+	var messages []*apitrace.Message
+	for _, file := range files {
+		data, err := instance.Handler().Command("cat", file).Output()
+		if err != nil {
+			return nil, fmt.Errorf("reading trace file %s failed: %w", file, err)
+		}
+		if _, err := instance.Handler().Command("rm", "-f", file).Output(); err != nil {
+			logrus.Debugf("removing trace file %s failed: %v", file, err)
+		}
+		trace, err := apitrace.ParseTrace(data, instance.BinapiMessages())
+		if err != nil {
+			logrus.Warnf("parsing trace file %s failed: %v", file, err)
+		}
+		if trace == nil {
+			continue
+		}
+		result.Wrapped = result.Wrapped || trace.Wrapped
+		messages = append(messages, trace.Messages...)
+	}
+	result.Calls = apitrace.PairCalls(messages)
+
+	return result, nil
+}
+
+func printApiTrace(out io.Writer, result *ApiTraceResult) {
+	if len(result.Calls) == 0 {
+		fmt.Fprintln(out, colorize(nonAvailableColor, "no API messages traced"))
+		return
+	}
+	if result.Wrapped {
+		fmt.Fprintln(out, colorize(noteColor, "note: trace buffer wrapped, older messages were dropped"))
+	}
+	for _, call := range result.Calls {
+		printApiTraceMessage(out, call.Request, "")
+		for _, reply := range call.Replies {
+			printApiTraceMessage(out, reply, "  -> ")
+		}
+	}
+}
+
+func printApiTraceMessage(out io.Writer, msg *apitrace.Message, prefix string) {
+	name := msg.Name
+	if name == "" {
+		name = fmt.Sprintf("#%d", msg.ID)
+	}
+	var info string
+	if msg.Error != "" {
+		info = colorize(nonAvailableColor, msg.Error)
+	} else if msg.Data != nil {
+		b, err := json.Marshal(msg.Data)
+		if err != nil {
+			info = colorize(nonAvailableColor, err)
+		} else {
+			info = colorize(valueColor, string(b))
+		}
+	}
+	fmt.Fprintf(out, "%s%s %s\n", prefix, colorize(highlightColor, name), info)
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"go.ligato.io/vpp-probe/probe"
+	"go.ligato.io/vpp-probe/vpp"
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+const countersExample = `  # Show counters that changed while running ping
+  vpp-probe counters --env kube -- ping -c 3 10.10.1.1
+
+  # Show counters that changed during 10s in JSON
+  vpp-probe counters --env kube -f json -- sleep 10`
+
+type CountersOptions struct {
+	CustomCmd string
+	Format    string
+}
+
+func NewCountersCmd(cli Cli) *cobra.Command {
+	var (
+		opts CountersOptions
+	)
+	cmd := &cobra.Command{
+		Use:     "counters [flags] -- [command]",
+		Short:   "Show counters of VPP instances changed by a command",
+		Long:    "Show error, interface and node counters of VPP instances that changed while executing a command (defaults to 'sleep 5')",
+		Example: countersExample,
+		Args:    cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				opts.CustomCmd = "sleep 5"
+			} else {
+				opts.CustomCmd = strings.Join(args, " ")
+			}
+			return RunCounters(cli, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.Format, "format", "f", "", "Output format (json, yaml, go-template..)")
+	return cmd
+}
+
+// InstanceCounters are counter increments of an instance.
+type InstanceCounters struct {
+	Instance string
+	*vpp.StatsDelta
+
+	handler probe.Handler
+}
+
+func RunCounters(cli Cli, opts CountersOptions) error {
+	if err := cli.Client().DiscoverInstances(cli.Queries()...); err != nil {
+		return err
+	}
+	instances := cli.Client().Instances()
+
+	before := make(map[*vpp.Instance]*api.VppStats, len(instances))
+	for _, instance := range instances {
+		stats, err := instance.DumpStats()
+		if err != nil {
+			logrus.Warnf("dumping stats for instance %v failed: %v", instance.ID(), err)
+			continue
+		}
+		before[instance] = stats
+	}
+	if len(before) == 0 {
+		return fmt.Errorf("failed to dump stats for instances")
+	}
+	logrus.Infof("stats collected for %d/%d instances", len(before), len(instances))
+
+	var commandErr error
+	cmd := exec.Command("sh", "-c", opts.CustomCmd)
+	cmd.Stderr = cli.Err()
+	cmd.Stdout = cli.Out()
+	logrus.Infof("running command: %v", cmd)
+
+	fmt.Fprintln(cli.Err())
+	if commandErr = cmd.Run(); commandErr != nil {
+		logrus.Warnf("command failed: %v", commandErr)
+	}
+	fmt.Fprintln(cli.Err())
+
+	var list []*InstanceCounters
+	for _, instance := range instances {
+		prev, ok := before[instance]
+		if !ok {
+			continue
+		}
+		stats, err := instance.DumpStats()
+		if err != nil {
+			logrus.Warnf("dumping stats for instance %v failed: %v", instance.ID(), err)
+			continue
+		}
+		list = append(list, &InstanceCounters{
+			Instance:   instance.ID(),
+			StatsDelta: vpp.DiffStats(prev, stats),
+			handler:    instance.Handler(),
+		})
+	}
+
+	if opts.Format != "" {
+		if err := formatAsTemplate(cli.Out(), opts.Format, list); err != nil {
+			return err
+		}
+	} else {
+		for _, c := range list {
+			printInstanceHeader(cli.Out(), c.handler)
+			printStatsDelta(cli.Out(), c.StatsDelta)
+		}
+	}
+
+	return commandErr
+}
+
+func printStatsDelta(out io.Writer, delta *vpp.StatsDelta) {
+	var buf bytes.Buffer
+
+	if delta.IsEmpty() {
+		fmt.Fprintf(&buf, "%s\n\n", colorize(nonAvailableColor, "no counters changed"))
+		fmt.Fprint(out, renderColor(buf.String()))
+		return
+	}
+
+	if len(delta.Errors) > 0 {
+		fmt.Fprintln(&buf, colorize(headerColor, "Error counters:"))
+		names := make([]string, 0, len(delta.Errors))
+		for name := range delta.Errors {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if delta.Errors[names[i]] != delta.Errors[names[j]] {
+				return delta.Errors[names[i]] > delta.Errors[names[j]]
+			}
+			return names[i] < names[j]
+		})
+		for _, name := range names {
+			fmt.Fprintf(&buf, "  %10s  %s\n", colorize(valueColor, fmt.Sprintf("+%d", delta.Errors[name])), name)
+		}
+		fmt.Fprintln(&buf)
+	}
+
+	w := tabwriter.NewWriter(&buf, 0, 1, 2, ' ', tabwriter.StripEscape|tabwriter.FilterHTML)
+	if len(delta.Interfaces) > 0 {
+		fmt.Fprintln(w, colorize(headerColor, "Interfaces:"))
+		fmt.Fprintln(w, "  INTERFACE\tRX\tTX\tDROPS\tPUNTS\tRX ERR\tTX ERR\tRX NOBUF\tRX MISS\t")
+		for _, d := range delta.Interfaces {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n",
+				colorize(interfaceColor, d.Interface),
+				formatDelta(d.RxPackets), formatDelta(d.TxPackets),
+				formatDelta(d.Drops), formatDelta(d.Punts),
+				formatDelta(d.RxErrors), formatDelta(d.TxErrors),
+				formatDelta(d.RxNoBuf), formatDelta(d.RxMiss),
+			)
+		}
+		fmt.Fprintln(w)
+	}
+	if len(delta.Nodes) > 0 {
+		fmt.Fprintln(w, colorize(headerColor, "Nodes:"))
+		fmt.Fprintln(w, "  NODE\tCALLS\tVECTORS\tSUSPENDS\tCLOCKS\t")
+		for _, d := range delta.Nodes {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t\n",
+				d.Node,
+				formatDelta(d.Calls), formatDelta(d.Vectors),
+				formatDelta(d.Suspends), formatDelta(d.Clocks),
+			)
+		}
+		fmt.Fprintln(w)
+	}
+	if err := w.Flush(); err != nil {
+		logrus.Warnf("flushing table failed: %v", err)
+	}
+
+	fmt.Fprint(out, renderColor(buf.String()))
+}
+
+func formatDelta(v uint64) string {
+	if v == 0 {
+		return colorize(nonAvailableColor, "-")
+	}
+	return fmt.Sprintf("+%d", v)
+}
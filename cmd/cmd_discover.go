@@ -41,12 +41,14 @@ func NewDiscoverCmd(cli Cli) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVarP(&opts.Format, "format", "f", "", "Output format (json, yaml, go-template..)")
 	flags.BoolVar(&opts.IPsecAgg, "ipsec-agg", false, "Print aggregated IPSec info")
+	flags.BoolVar(&opts.PerWorker, "per-worker", false, "Print per-worker breakdown of error counters and node stats")
 	return cmd
 }
 
 type DiscoverOptions struct {
-	Format   string
-	IPsecAgg bool
+	Format    string
+	IPsecAgg  bool
+	PerWorker bool
 }
 
 func RunDiscover(cli Cli, opts DiscoverOptions) error {
@@ -65,19 +67,20 @@ func RunDiscover(cli Cli, opts DiscoverOptions) error {
 	instch := make(chan *agent.Instance, len(instances))
 
 	if err := client.RunOnInstances(instances, func(instance *vpp.Instance) error {
-		if instance.Agent() == nil {
-			return fmt.Errorf("agent not found for instance %v", instance.ID())
-		}
 		logrus.Debugf("- updating vpp info %+v: %v", instance.ID(), instance.Status())
 
-		err := instance.Agent().UpdateInstanceInfo()
+		err := instance.UpdateConfig()
 		if err != nil {
 			return fmt.Errorf("instance %v error: %v", instance.ID(), err)
 		}
-		instch <- instance.Agent()
+		if instance.Agent() != nil {
+			instch <- instance.Agent()
+		} else {
+			instch <- agent.NewInstanceFromConfig(instance.Handler(), instance.Config())
+		}
 
 		if format := opts.Format; len(format) == 0 {
-			printDiscoverTable(cli.Out(), instance)
+			printDiscoverTable(cli.Out(), instance, opts.PerWorker)
 		} else {
 			if err := formatAsTemplate(cli.Out(), format, instance); err != nil {
 				return err
@@ -108,18 +111,22 @@ func RunDiscover(cli Cli, opts DiscoverOptions) error {
 	return nil
 }
 
-func printDiscoverTable(out io.Writer, instance *vpp.Instance) {
+func printDiscoverTable(out io.Writer, instance *vpp.Instance, perWorker bool) {
 	var buf bytes.Buffer
 
 	printInstanceHeader(&buf, instance.Handler())
 
 	printDiscoveredInstance(strutil.IndentedWriter(&buf), instance)
 
+	if perWorker {
+		printPerWorkerStats(strutil.IndentedWriter(&buf), instance.VppStats())
+	}
+
 	fmt.Fprint(out, renderColor(buf.String()))
 }
 
 func printDiscoveredInstance(out io.Writer, instance *vpp.Instance) {
-	config := instance.Agent().Config
+	config := instance.Config()
 
 	// Info
 	{
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"go.ligato.io/vpp-probe/client"
+	"go.ligato.io/vpp-probe/probe"
+	"go.ligato.io/vpp-probe/vpp"
+	"go.ligato.io/vpp-probe/vpp/agent"
+)
+
+const driftExample = `  # Show config drift of agents in Kubernetes
+  vpp-probe drift -e kube
+
+  # Show config drift in JSON
+  vpp-probe drift -e kube -f json`
+
+type DriftOptions struct {
+	Format string
+}
+
+func NewDriftCmd(cli Cli) *cobra.Command {
+	var (
+		opts DriftOptions
+	)
+	cmd := &cobra.Command{
+		Use:     "drift [options]",
+		Short:   "Show drift between intended and actual agent config",
+		Long:    "Show keys of agent config intended by NB that are missing, different or failed in VPP with reason",
+		Example: driftExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunDrift(cli, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.Format, "format", "f", "", "Output format (json, yaml, go-template..)")
+	return cmd
+}
+
+// InstanceDrift is config drift of an instance.
+type InstanceDrift struct {
+	Instance string
+	Drifts   []agent.Drift
+
+	handler probe.Handler
+}
+
+func RunDrift(cli Cli, opts DriftOptions) error {
+	if err := cli.Client().DiscoverInstances(cli.Queries()...); err != nil {
+		return err
+	}
+	instances, skipped, err := agentInstances(cli.Client().Instances())
+	if err != nil {
+		return err
+	}
+
+	driftch := make(chan *InstanceDrift, len(instances))
+
+	if err := client.RunOnInstances(instances, func(instance *vpp.Instance) error {
+		drifts, err := agent.DetectDrift(instance.Agent().Client())
+		if err != nil {
+			return fmt.Errorf("instance %v error: %w", instance.ID(), err)
+		}
+		driftch <- &InstanceDrift{
+			Instance: instance.ID(),
+			Drifts:   drifts,
+			handler:  instance.Handler(),
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	close(driftch)
+
+	var list []*InstanceDrift
+	for d := range driftch {
+		list = append(list, d)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Instance < list[j].Instance
+	})
+
+	if opts.Format != "" {
+		return formatAsTemplate(cli.Out(), opts.Format, list)
+	}
+	for _, d := range list {
+		printInstanceDrift(cli.Out(), d)
+	}
+	printSkippedInstances(cli.Out(), skipped)
+	return nil
+}
+
+func printInstanceDrift(out io.Writer, d *InstanceDrift) {
+	var buf bytes.Buffer
+
+	printInstanceHeader(&buf, d.handler)
+
+	if len(d.Drifts) == 0 {
+		fmt.Fprintf(&buf, "%s\n\n", colorize(statusUpColor, "no drift"))
+		fmt.Fprint(out, renderColor(buf.String()))
+		return
+	}
+
+	for _, drift := range d.Drifts {
+		kindColor := noteColor
+		switch drift.Kind {
+		case agent.DriftFailed:
+			kindColor = statusDownColor
+		case agent.DriftMissing:
+			kindColor = interfaceColor
+		}
+		fmt.Fprintf(&buf, "%s %s", colorize(kindColor, fmt.Sprintf("%-10s", drift.Kind)), drift.Key)
+		if drift.State != "" {
+			fmt.Fprintf(&buf, " %s", colorize(nonAvailableColor, "["+drift.State+"]"))
+		}
+		fmt.Fprintln(&buf)
+		if drift.Reason != "" {
+			fmt.Fprintf(&buf, "           reason: %s\n", drift.Reason)
+		}
+		for _, diff := range drift.Diffs {
+			fmt.Fprintf(&buf, "           %s\n", colorize(valueColor, diff))
+		}
+	}
+	fmt.Fprintln(&buf)
+
+	fmt.Fprint(out, renderColor(buf.String()))
+}
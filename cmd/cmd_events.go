@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"go.ligato.io/vpp-probe/vpp"
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+const eventsExample = `  # Stream interface events from all VPP instances in Kubernetes
+  vpp-probe events -e kube
+
+  # Stream interface events as JSON lines for 1 hour
+  vpp-probe events -e kube -f json --duration 1h`
+
+type EventsOptions struct {
+	Format       string
+	PollInterval time.Duration
+	Duration     time.Duration
+}
+
+func NewEventsCmd(cli Cli) *cobra.Command {
+	var (
+		opts = EventsOptions{
+			PollInterval: vpp.DefaultEventsPollInterval,
+		}
+	)
+	cmd := &cobra.Command{
+		Use:     "events [options]",
+		Short:   "Stream interface events from VPP instances",
+		Long:    "Stream interface admin/link state changes from VPP instances until interrupted",
+		Example: eventsExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunEvents(cli, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.Format, "format", "f", "", "Output format (json)")
+	flags.DurationVar(&opts.PollInterval, "interval", opts.PollInterval, "Interval for polling interface status when events are not supported")
+	flags.DurationVar(&opts.Duration, "duration", 0, "Stop streaming after duration (0 streams until interrupted)")
+	return cmd
+}
+
+// InstanceEvent is an interface event from an instance.
+type InstanceEvent struct {
+	Instance string
+	api.InterfaceEvent
+}
+
+func RunEvents(cli Cli, opts EventsOptions) error {
+	if opts.Format != "" && opts.Format != "json" {
+		return fmt.Errorf("unsupported format: %q", opts.Format)
+	}
+
+	if err := cli.Client().DiscoverInstances(cli.Queries()...); err != nil {
+		return err
+	}
+	instances := cli.Client().Instances()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	if opts.Duration > 0 {
+		ctx, cancel = context.WithTimeout(ctx, opts.Duration)
+		defer cancel()
+	}
+
+	eventch := make(chan InstanceEvent)
+
+	var wg sync.WaitGroup
+	for _, instance := range instances {
+		events, err := instance.WatchInterfaceEvents(ctx, opts.PollInterval)
+		if err != nil {
+			logrus.Warnf("watching events for instance %v failed: %v", instance.ID(), err)
+			continue
+		}
+		wg.Add(1)
+		go func(instance *vpp.Instance) {
+			defer wg.Done()
+			for event := range events {
+				eventch <- InstanceEvent{
+					Instance:       instance.Handler().ID(),
+					InterfaceEvent: event,
+				}
+			}
+		}(instance)
+	}
+	go func() {
+		wg.Wait()
+		close(eventch)
+	}()
+
+	logrus.Infof("streaming interface events from %d instances", len(instances))
+
+	for event := range eventch {
+		if opts.Format == "json" {
+			if err := printEventJSON(cli.Out(), event); err != nil {
+				return err
+			}
+		} else {
+			printEventText(cli.Out(), event)
+		}
+	}
+
+	return nil
+}
+
+func printEventJSON(out io.Writer, event InstanceEvent) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(b))
+	return err
+}
+
+func printEventText(out io.Writer, event InstanceEvent) {
+	var state string
+	if event.Deleted {
+		state = colorize(nonAvailableColor, "deleted")
+	} else {
+		state = strings.Join([]string{
+			fmt.Sprintf("admin %v", colorizedUpDown(event.Status.Up)),
+			fmt.Sprintf("link %v", colorizedUpDown(event.Status.Link)),
+		}, " / ")
+	}
+	line := fmt.Sprintf("%s  %s  %s (%d)  %s\n",
+		event.Time.Format(time.RFC3339), colorize(highlightColor, event.Instance),
+		colorize(interfaceColor, event.Name), event.Index, state)
+	fmt.Fprint(out, renderColor(line))
+}
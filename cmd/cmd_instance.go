@@ -14,6 +14,7 @@ import (
 	"github.com/spf13/cobra"
 	"go.ligato.io/vpp-probe/vpp/agent"
 
+	"go.ligato.io/vpp-probe/client"
 	"go.ligato.io/vpp-probe/vpp"
 )
 
@@ -31,7 +32,9 @@ const instancesExample = `  # List VPP instances in Kubernetes cluster
 
 func NewInstancesCmd(cli Cli) *cobra.Command {
 	var (
-		opts InstancesOptions
+		opts = InstancesOptions{
+			TxnsSince: time.Hour,
+		}
 	)
 	cmd := &cobra.Command{
 		Use:   "instances [options]",
@@ -42,13 +45,15 @@ func NewInstancesCmd(cli Cli) *cobra.Command {
 		Example: instancesExample,
 	}
 	flags := cmd.Flags()
+	flags.DurationVar(&opts.TxnsSince, "txns-since", opts.TxnsSince, "Count agent transactions newer than relative duration (all if zero)")
 	flags.StringVarP(&opts.Format, "format", "f", "", "Output format (json, yaml, go-template..)")
 	return cmd
 }
 
 type InstancesOptions struct {
-	Format   string
-	IPsecAgg bool
+	Format    string
+	TxnsSince time.Duration
+	IPsecAgg  bool
 }
 
 func RunInstances(cli Cli, opts InstancesOptions) error {
@@ -60,6 +65,27 @@ func RunInstances(cli Cli, opts InstancesOptions) error {
 
 	logrus.Debugf("discovered %d vpp instances", len(instances))
 
+	var since time.Time
+	if opts.TxnsSince > 0 {
+		since = time.Now().Add(-opts.TxnsSince)
+	}
+	var agents []*vpp.Instance
+	for _, instance := range instances {
+		if instance.Agent() != nil {
+			agents = append(agents, instance)
+		}
+	}
+	if len(agents) > 0 {
+		if err := client.RunOnInstances(agents, func(instance *vpp.Instance) error {
+			if err := instance.Agent().UpdateTxnHistory(since); err != nil {
+				logrus.Debugf("instance %v: %v", instance.ID(), err)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
 	if format := opts.Format; len(format) == 0 {
 		printInstancesTable(cli.Out(), instances)
 	} else {
@@ -108,8 +134,18 @@ func printInstancesTable(out io.Writer, instances []*vpp.Instance) {
 }
 
 func formatAgentTransactions(instance *agent.Instance) string {
-
-	return fmt.Sprint()
+	if instance == nil || len(instance.Txns) == 0 {
+		return ""
+	}
+	count := fmt.Sprint(len(instance.Txns))
+	lastErr := agent.LastError(instance.Txns)
+	if lastErr == "" {
+		return count
+	}
+	if len(lastErr) > 50 {
+		lastErr = lastErr[:47] + "..."
+	}
+	return fmt.Sprintf("%s %s", count, colorize(color.Red, "("+lastErr+")"))
 }
 
 func formatVppInterfacesColumn(instance *vpp.Instance) string {
@@ -139,5 +175,24 @@ func formatAgentColumn(agent *agent.Instance) string {
 		return "N/A"
 	}
 
-	return fmt.Sprintf("%v", agent.Info.Status.BuildVersion)
+	info := agent.Info
+	col := fmt.Sprintf("%v", info.Status.BuildVersion)
+	if info.Status.State != "" {
+		if info.IsReady() {
+			col += " " + colorize(color.Green, info.Status.State)
+		} else {
+			col += " " + colorize(color.Red, info.Status.State)
+		}
+	}
+	if notReady := info.NotReadyPlugins(); len(notReady) > 0 {
+		var names []string
+		for _, p := range notReady {
+			names = append(names, p.Name+"="+p.State)
+		}
+		col += " " + colorize(color.Red, "("+strings.Join(names, ",")+")")
+	}
+	if info.Transport != "" {
+		col += " " + colorize(nonAvailableColor, "("+string(info.Transport)+")")
+	}
+	return col
 }
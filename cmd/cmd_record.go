@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"go.ligato.io/vpp-probe/vpp/record"
+)
+
+const recordExample = `  # Record stats of VPP instances in Kubernetes every 5s for 1h as Influx line protocol
+  vpp-probe record -e kube --interval 5s --duration 1h --out stats.lp
+
+  # Record stats until interrupted as CSV to stdout
+  vpp-probe record --format csv`
+
+type RecordOptions struct {
+	Interval time.Duration
+	Duration time.Duration
+	Out      string
+	Format   string
+}
+
+func NewRecordCmd(cli Cli) *cobra.Command {
+	var (
+		opts = RecordOptions{
+			Interval: 5 * time.Second,
+			Out:      "-",
+		}
+	)
+	cmd := &cobra.Command{
+		Use:     "record [options]",
+		Short:   "Record stats of VPP instances to file",
+		Long:    "Record stats of VPP instances periodically to file as newline-delimited JSON, Influx line protocol or CSV with instance metadata as tags",
+		Example: recordExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunRecord(cli, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.DurationVar(&opts.Interval, "interval", opts.Interval, "Interval between stats samples")
+	flags.DurationVar(&opts.Duration, "duration", 0, "Duration of recording (records until interrupted if zero)")
+	flags.StringVarP(&opts.Out, "out", "o", opts.Out, "Output file (- for stdout)")
+	flags.StringVar(&opts.Format, "format", "", "Recording format (json, lp, csv), detected from output file extension by default")
+	return cmd
+}
+
+func RunRecord(cli Cli, opts RecordOptions) error {
+	if opts.Interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	format := record.Format(opts.Format)
+	if format == "" {
+		format = record.DetectFormat(opts.Out)
+	}
+
+	var out io.Writer = cli.Out()
+	if opts.Out != "-" && opts.Out != "" {
+		f, err := os.Create(opts.Out)
+		if err != nil {
+			return fmt.Errorf("creating output file failed: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	w, err := record.NewWriter(out, format)
+	if err != nil {
+		return err
+	}
+
+	if err := cli.Client().DiscoverInstances(cli.Queries()...); err != nil {
+		return err
+	}
+	instances := cli.Client().Instances()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	if opts.Duration > 0 {
+		ctx, cancel = context.WithTimeout(ctx, opts.Duration)
+		defer cancel()
+	}
+
+	logrus.Infof("recording stats of %d instances every %v to %s (%s)", len(instances), opts.Interval, opts.Out, format)
+
+	var samples int
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+	for {
+		now := time.Now()
+		for _, instance := range instances {
+			stats, err := instance.DumpStats()
+			if err != nil {
+				logrus.Warnf("dumping stats for instance %v failed: %v", instance.ID(), err)
+				continue
+			}
+			tags := map[string]string{}
+			for k, v := range instance.Handler().Metadata() {
+				if v != "" {
+					tags[k] = v
+				}
+			}
+			if err := w.Write(&record.Record{
+				Time:     now,
+				Instance: instance.ID(),
+				Tags:     tags,
+				Stats:    stats,
+			}); err != nil {
+				return fmt.Errorf("writing record failed: %w", err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return fmt.Errorf("writing records failed: %w", err)
+		}
+		samples++
+		logrus.Debugf("recorded sample #%d", samples)
+
+		select {
+		case <-ctx.Done():
+			logrus.Infof("recorded %d samples", samples)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"go.ligato.io/vpp-probe/vpp"
+	"go.ligato.io/vpp-probe/vpp/record"
+)
+
+const replayStatsExample = `  # Summarize peaks from recording
+  vpp-probe replay-stats stats.lp
+
+  # Summarize top 5 error counters from recording in JSON
+  vpp-probe replay-stats stats.csv --top 5 -f json`
+
+type ReplayStatsOptions struct {
+	File        string
+	InputFormat string
+	Top         int
+	Format      string
+}
+
+func NewReplayStatsCmd(cli Cli) *cobra.Command {
+	var (
+		opts = ReplayStatsOptions{
+			Top: 10,
+		}
+	)
+	cmd := &cobra.Command{
+		Use:     "replay-stats FILE",
+		Short:   "Summarize stats recording",
+		Long:    "Compute rates from stats recording created by record command and summarize peaks per instance",
+		Example: replayStatsExample,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.File = args[0]
+			return RunReplayStats(cli, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opts.InputFormat, "input-format", "", "Recording format (json, lp, csv), detected from file extension by default")
+	flags.IntVar(&opts.Top, "top", opts.Top, "Number of error counters with highest peak rates to show")
+	flags.StringVarP(&opts.Format, "format", "f", "", "Output format (json, yaml, go-template..)")
+	return cmd
+}
+
+// InstancePeaks are peak rates of an instance from recording.
+type InstancePeaks struct {
+	Instance string
+	Tags     map[string]string `json:",omitempty"`
+	*vpp.StatsPeaks
+}
+
+func RunReplayStats(cli Cli, opts ReplayStatsOptions) error {
+	format := record.Format(opts.InputFormat)
+	if format == "" {
+		format = record.DetectFormat(opts.File)
+	}
+
+	f, err := os.Open(opts.File)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	records, err := record.ReadRecords(f, format)
+	if err != nil {
+		return fmt.Errorf("reading recording failed: %w", err)
+	}
+	logrus.Debugf("read %d records from %s", len(records), opts.File)
+
+	var instances []string
+	samples := map[string][]vpp.StatsSample{}
+	tags := map[string]map[string]string{}
+	for _, rec := range records {
+		if _, ok := samples[rec.Instance]; !ok {
+			instances = append(instances, rec.Instance)
+			tags[rec.Instance] = rec.Tags
+		}
+		samples[rec.Instance] = append(samples[rec.Instance], vpp.StatsSample{
+			Time:  rec.Time,
+			Stats: rec.Stats,
+		})
+	}
+	sort.Strings(instances)
+
+	var list []*InstancePeaks
+	for _, instance := range instances {
+		s := samples[instance]
+		sort.SliceStable(s, func(i, j int) bool {
+			return s[i].Time.Before(s[j].Time)
+		})
+		list = append(list, &InstancePeaks{
+			Instance:   instance,
+			Tags:       tags[instance],
+			StatsPeaks: vpp.SummarizePeaks(s),
+		})
+	}
+
+	if opts.Format != "" {
+		return formatAsTemplate(cli.Out(), opts.Format, list)
+	}
+	printStatsPeaks(cli.Out(), list, opts.Top)
+	return nil
+}
+
+func printStatsPeaks(out io.Writer, list []*InstancePeaks, top int) {
+	var buf bytes.Buffer
+
+	formatPeak := func(p vpp.RatePeak, unit string) string {
+		if p.Rate == 0 {
+			return formatRate(0, unit)
+		}
+		return fmt.Sprintf("%s %s", formatRate(p.Rate, unit), colorize(nonAvailableColor, "@"+p.Time.Format("15:04:05")))
+	}
+
+	for _, p := range list {
+		fmt.Fprintf(&buf, "%s %s\n", colorize(instanceHeaderColor, p.Instance),
+			colorize(nonAvailableColor, fmt.Sprintf("%d samples, %s - %s (%v)",
+				p.Samples, p.Start.Format(time.RFC3339), p.End.Format(time.RFC3339), p.End.Sub(p.Start).Round(time.Second))))
+
+		w := tabwriter.NewWriter(&buf, 0, 1, 2, ' ', tabwriter.StripEscape|tabwriter.FilterHTML)
+		fmt.Fprintln(w, "  INTERFACE\tPEAK RX PPS\tPEAK RX BPS\tPEAK TX PPS\tPEAK TX BPS\tPEAK DROPS/s\t")
+		for _, iface := range p.Interfaces {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\t\n",
+				colorize(interfaceColor, iface.Interface),
+				formatPeak(iface.RxPps, ""),
+				formatPeak(iface.RxBps, "b"),
+				formatPeak(iface.TxPps, ""),
+				formatPeak(iface.TxBps, "b"),
+				formatPeak(iface.DropRate, ""),
+			)
+		}
+		if err := w.Flush(); err != nil {
+			logrus.Warnf("flushing table failed: %v", err)
+		}
+
+		if len(p.Errors) > 0 {
+			names := make([]string, 0, len(p.Errors))
+			for name := range p.Errors {
+				names = append(names, name)
+			}
+			sort.Slice(names, func(i, j int) bool {
+				return p.Errors[names[i]].Rate > p.Errors[names[j]].Rate
+			})
+			if top > 0 && len(names) > top {
+				names = names[:top]
+			}
+			fmt.Fprintf(&buf, "\n  Peak error counters:\n")
+			for _, name := range names {
+				fmt.Fprintf(&buf, "  %10s/s  %s  %s\n", formatRate(p.Errors[name].Rate, ""), name,
+					colorize(nonAvailableColor, "@"+p.Errors[name].Time.Format("15:04:05")))
+			}
+		}
+		fmt.Fprintln(&buf)
+	}
+
+	fmt.Fprint(out, renderColor(buf.String()))
+}
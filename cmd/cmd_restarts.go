@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"go.ligato.io/vpp-probe/pkg/strutil"
+	"go.ligato.io/vpp-probe/vpp"
+)
+
+const restartsExample = `  # Check VPP instances in Kubernetes for restarts since last check
+  vpp-probe restarts -e kube
+
+  # Watch VPP instances for restarts every 10s
+  vpp-probe restarts -e kube --watch 10s`
+
+type RestartsOptions struct {
+	StateFile string
+	CoreDir   string
+	LogLines  int
+	Watch     time.Duration
+	Format    string
+}
+
+func NewRestartsCmd(cli Cli) *cobra.Command {
+	var (
+		opts = RestartsOptions{
+			StateFile: vpp.DefaultRunStatesFile(),
+			CoreDir:   vpp.DefaultCoreDir,
+			LogLines:  vpp.DefaultPostMortemLogLines,
+		}
+	)
+	cmd := &cobra.Command{
+		Use:     "restarts [options]",
+		Short:   "Detect restarts of VPP instances",
+		Long:    "Detect restarts of VPP instances since last check and collect post-mortem data for restarted instances",
+		Example: restartsExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunRestarts(cli, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opts.StateFile, "statefile", opts.StateFile, "File for storing state of instances between runs")
+	flags.StringVar(&opts.CoreDir, "coredir", opts.CoreDir, "Directory with core files on the instance host")
+	flags.IntVar(&opts.LogLines, "loglines", opts.LogLines, "Number of log lines to collect from previous run")
+	flags.DurationVar(&opts.Watch, "watch", 0, "Keep checking for restarts with interval until interrupted")
+	flags.StringVarP(&opts.Format, "format", "f", "", "Output format (json, yaml, go-template..)")
+	return cmd
+}
+
+// InstanceRestart is a result of restart check for an instance.
+type InstanceRestart struct {
+	Instance string
+	State    vpp.RunState
+	Restart  *vpp.Restart `json:",omitempty"`
+
+	instance *vpp.Instance
+}
+
+func RunRestarts(cli Cli, opts RestartsOptions) error {
+	if err := cli.Client().DiscoverInstances(cli.Queries()...); err != nil {
+		return err
+	}
+	instances := cli.Client().Instances()
+
+	states, err := vpp.LoadRunStates(opts.StateFile)
+	if err != nil {
+		logrus.Warnf("loading run states failed: %v", err)
+		states = map[string]vpp.RunState{}
+	}
+
+	results := checkRestarts(instances, states, opts)
+	if err := vpp.SaveRunStates(opts.StateFile, states); err != nil {
+		logrus.Warnf("saving run states failed: %v", err)
+	}
+	if err := printRestarts(cli.Out(), results, opts.Format, true); err != nil {
+		return err
+	}
+
+	if opts.Watch <= 0 {
+		return nil
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	logrus.Infof("watching %d instances for restarts every %v", len(instances), opts.Watch)
+
+	t := time.NewTicker(opts.Watch)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+		results := checkRestarts(instances, states, opts)
+		if err := vpp.SaveRunStates(opts.StateFile, states); err != nil {
+			logrus.Warnf("saving run states failed: %v", err)
+		}
+		if err := printRestarts(cli.Out(), results, opts.Format, false); err != nil {
+			return err
+		}
+	}
+}
+
+func checkRestarts(instances []*vpp.Instance, states map[string]vpp.RunState, opts RestartsOptions) []*InstanceRestart {
+	var results []*InstanceRestart
+	for _, instance := range instances {
+		state, err := instance.CheckRunState()
+		if err != nil {
+			logrus.Warnf("checking instance %v failed: %v", instance.ID(), err)
+			continue
+		}
+		result := &InstanceRestart{
+			Instance: instance.ID(),
+			State:    state,
+			instance: instance,
+		}
+		if prev, ok := states[instance.ID()]; ok {
+			if restart := vpp.DetectRestart(prev, state); restart != nil {
+				logrus.Debugf("instance %v restarted: %v", instance.ID(), restart.Reason)
+				restart.PostMortem = instance.CollectPostMortem(opts.CoreDir, opts.LogLines)
+				result.Restart = restart
+			}
+		}
+		states[instance.ID()] = state
+		results = append(results, result)
+	}
+	return results
+}
+
+func printRestarts(out io.Writer, results []*InstanceRestart, format string, all bool) error {
+	if !all {
+		var restarted []*InstanceRestart
+		for _, r := range results {
+			if r.Restart != nil {
+				restarted = append(restarted, r)
+			}
+		}
+		if len(restarted) == 0 {
+			return nil
+		}
+		results = restarted
+	}
+	if format != "" {
+		return formatAsTemplate(out, format, results)
+	}
+	for _, r := range results {
+		var buf bytes.Buffer
+
+		printInstanceHeader(&buf, r.instance.Handler())
+		printRestart(strutil.IndentedWriter(&buf), r)
+
+		fmt.Fprint(out, renderColor(buf.String()))
+	}
+	return nil
+}
+
+func printRestart(out io.Writer, r *InstanceRestart) {
+	fmt.Fprintf(out, "PID: %v  Uptime: %v  Checked: %v\n",
+		colorize(valueColor, r.State.Pid), colorize(valueColor, r.State.Uptime),
+		r.State.CheckedAt.Format(time.RFC3339))
+
+	if r.Restart == nil {
+		fmt.Fprintln(out, colorize(statusUpColor, "no restart detected"))
+		return
+	}
+	fmt.Fprintf(out, "%s: %s\n", colorize(statusDownColor, "RESTART DETECTED"), r.Restart.Reason)
+
+	pm := r.Restart.PostMortem
+	if pm == nil {
+		return
+	}
+	if c := pm.Container; c != nil {
+		fmt.Fprintf(out, "Container: %s (restarts: %d)\n", c.Status, c.RestartCount)
+		if !c.LastFinishedAt.IsZero() {
+			fmt.Fprintf(out, "Last termination: exit code %s, reason %q at %v\n",
+				colorize(highlightColor, c.LastExitCode), c.LastReason, c.LastFinishedAt.Format(time.RFC3339))
+		}
+	}
+	if len(pm.CoreFiles) > 0 {
+		fmt.Fprintln(out, "Core files:")
+		for _, f := range pm.CoreFiles {
+			fmt.Fprintf(out, "  %s\n", colorize(filePathColor, f))
+		}
+	}
+	if len(pm.Logs) > 0 {
+		fmt.Fprintln(out, "Previous log:")
+		for _, line := range pm.Logs {
+			fmt.Fprintf(out, "  %s\n", line)
+		}
+	}
+	for _, e := range pm.Errors {
+		fmt.Fprintf(out, "%s %s\n", colorize(nonAvailableColor, "collecting failed:"), e)
+	}
+}
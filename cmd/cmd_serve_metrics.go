@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"go.ligato.io/vpp-probe/client"
+	"go.ligato.io/vpp-probe/pkg/metrics"
+	"go.ligato.io/vpp-probe/vpp"
+)
+
+const serveMetricsExample = `  # Serve metrics of VPP instances in Kubernetes
+  vpp-probe serve-metrics -e kube --listen :9482
+
+  # Scrape metrics
+  curl http://localhost:9482/metrics`
+
+type ServeMetricsOptions struct {
+	Listen           string
+	DiscoverInterval time.Duration
+	Labels           []string
+}
+
+var DefaultServeMetricsOptions = ServeMetricsOptions{
+	Listen:           ":9482",
+	DiscoverInterval: time.Minute,
+	Labels:           []string{"env", "cluster", "namespace", "pod", "node", "container"},
+}
+
+func NewServeMetricsCmd(cli Cli) *cobra.Command {
+	var (
+		opts = DefaultServeMetricsOptions
+	)
+	cmd := &cobra.Command{
+		Use:     "serve-metrics [options]",
+		Short:   "Serve stats of VPP instances as Prometheus metrics",
+		Long:    "Serve stats of discovered VPP instances as Prometheus metrics via HTTP while periodically re-running discovery",
+		Example: serveMetricsExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunServeMetrics(cli, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opts.Listen, "listen", opts.Listen, "Address to listen on for HTTP requests")
+	flags.DurationVar(&opts.DiscoverInterval, "discover-interval", opts.DiscoverInterval, "Interval for re-running instance discovery")
+	flags.StringSliceVar(&opts.Labels, "labels", opts.Labels, "Instance metadata keys added as labels to metrics")
+	return cmd
+}
+
+func RunServeMetrics(cli Cli, opts ServeMetricsOptions) error {
+	exporter := &metricsExporter{
+		cli:    cli,
+		labels: opts.Labels,
+	}
+	if err := exporter.discover(); err != nil {
+		logrus.Warnf("discovery failed: %v", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if opts.DiscoverInterval > 0 {
+		go func() {
+			t := time.NewTicker(opts.DiscoverInterval)
+			defer t.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-t.C:
+				}
+				if err := exporter.discover(); err != nil {
+					logrus.Warnf("discovery failed: %v", err)
+				}
+			}
+		}()
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exporter)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `<html><body><a href="/metrics">Metrics</a></body></html>`)
+	})
+	server := &http.Server{
+		Addr:    opts.Listen,
+		Handler: mux,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logrus.Warnf("server shutdown failed: %v", err)
+		}
+	}()
+
+	logrus.Infof("serving metrics on %v/metrics", opts.Listen)
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+type metricsExporter struct {
+	cli    Cli
+	labels []string
+
+	mu        sync.RWMutex
+	instances []*vpp.Instance
+}
+
+// discover re-runs discovery keeping instances that did not change and
+// closes instances that were removed.
+func (e *metricsExporter) discover() error {
+	removed, err := e.cli.Client().RediscoverInstances(e.cli.Queries()...)
+	instances := e.cli.Client().Instances()
+
+	e.mu.Lock()
+	e.instances = instances
+	e.mu.Unlock()
+
+	for _, instance := range removed {
+		if err := instance.Handler().Close(); err != nil {
+			logrus.Debugf("closing handler %v failed: %v", instance.ID(), err)
+		}
+	}
+
+	logrus.Debugf("discovered %d instances (%d removed)", len(instances), len(removed))
+	return err
+}
+
+func (e *metricsExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	set := metrics.NewSet()
+	if len(e.instances) > 0 {
+		var mu sync.Mutex
+		err := client.RunOnInstances(e.instances, func(instance *vpp.Instance) error {
+			labels := e.instanceLabels(instance)
+			stats, err := instance.DumpStats()
+
+			mu.Lock()
+			defer mu.Unlock()
+			metrics.AddUp(set, labels, err == nil)
+			if err != nil {
+				return fmt.Errorf("dumping stats for instance %v failed: %w", instance.ID(), err)
+			}
+			metrics.AddVppStats(set, stats, labels)
+			return nil
+		})
+		if err != nil {
+			logrus.Debugf("collecting metrics failed: %v", err)
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := set.WriteText(w); err != nil {
+		logrus.Debugf("writing metrics failed: %v", err)
+	}
+}
+
+func (e *metricsExporter) instanceLabels(instance *vpp.Instance) metrics.Labels {
+	metadata := instance.Handler().Metadata()
+	labels := metrics.Labels{
+		metrics.InstanceLabel: instance.Handler().ID(),
+	}
+	for _, key := range e.labels {
+		if v := metadata[key]; v != "" {
+			labels[metrics.SanitizeName(key)] = v
+		}
+	}
+	return labels
+}
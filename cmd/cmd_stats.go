@@ -0,0 +1,392 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"go.ligato.io/vpp-probe/pkg/strutil"
+	"go.ligato.io/vpp-probe/probe"
+	"go.ligato.io/vpp-probe/vpp"
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+const statsExample = `  # Show interface rates of VPP instances in Kubernetes
+  vpp-probe stats -e kube
+
+  # Refresh interface rates every 2s sorted by RX packets
+  vpp-probe stats -e kube --watch --interval 2s --sort rx-pps
+
+  # Get drop error counters, node clocks and buffer pools in JSON
+  vpp-probe stats get '/err/*drop*' '/nodes/*/clocks' '/buffer-pools/*' -f json`
+
+type StatsOptions struct {
+	Interval  time.Duration
+	Watch     bool
+	Sort      string
+	PerWorker bool
+	Format    string
+}
+
+func NewStatsCmd(cli Cli) *cobra.Command {
+	var (
+		opts = StatsOptions{
+			Interval: time.Second,
+			Sort:     "name",
+		}
+	)
+	cmd := &cobra.Command{
+		Use:     "stats [options]",
+		Short:   "Show interface traffic rates of VPP instances",
+		Long:    "Show interface traffic rates and error counter rates of VPP instances computed from stats sampled over interval",
+		Example: statsExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunStats(cli, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.DurationVar(&opts.Interval, "interval", opts.Interval, "Interval between stats samples")
+	flags.BoolVarP(&opts.Watch, "watch", "w", false, "Refresh rates periodically until interrupted")
+	flags.StringVar(&opts.Sort, "sort", opts.Sort, "Sort interfaces by (name, rx-pps, tx-pps, rx-bps, tx-bps, drops)")
+	flags.BoolVar(&opts.PerWorker, "per-worker", false, "Show per-worker rates of error counters and node vectors")
+	flags.StringVarP(&opts.Format, "format", "f", "", "Output format (json, yaml, go-template..)")
+	cmd.AddCommand(NewStatsGetCmd(cli))
+	return cmd
+}
+
+// InstanceRates are stats rates of an instance.
+type InstanceRates struct {
+	Instance string
+	*vpp.StatsRates
+}
+
+func RunStats(cli Cli, opts StatsOptions) error {
+	less, err := interfaceRatesLess(opts.Sort)
+	if err != nil {
+		return err
+	}
+	if opts.Interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+
+	if err := cli.Client().DiscoverInstances(cli.Queries()...); err != nil {
+		return err
+	}
+	instances := cli.Client().Instances()
+
+	samplers := make(map[*vpp.Instance]*vpp.StatsSampler, len(instances))
+	for _, instance := range instances {
+		samplers[instance] = vpp.NewStatsSampler(instance)
+	}
+	sample := func() []*InstanceRates {
+		var list []*InstanceRates
+		for _, instance := range instances {
+			rates, err := samplers[instance].Sample()
+			if err != nil {
+				logrus.Warnf("sampling stats for instance %v failed: %v", instance.ID(), err)
+				continue
+			}
+			if rates == nil {
+				continue
+			}
+			sort.SliceStable(rates.Interfaces, func(i, j int) bool {
+				return less(rates.Interfaces[i], rates.Interfaces[j])
+			})
+			list = append(list, &InstanceRates{
+				Instance:   instance.ID(),
+				StatsRates: rates,
+			})
+		}
+		return list
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	sample()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.Interval):
+		}
+		rates := sample()
+
+		if opts.Format != "" {
+			if err := formatAsTemplate(cli.Out(), opts.Format, rates); err != nil {
+				return err
+			}
+		} else {
+			if opts.Watch {
+				// clear screen and move cursor to top
+				fmt.Fprint(cli.Out(), "\033[H\033[2J")
+				fmt.Fprintf(cli.Out(), "Every %v: %s\n\n", opts.Interval, time.Now().Format(time.RFC3339))
+			}
+			printStatsRates(cli.Out(), rates)
+			if opts.PerWorker {
+				printPerWorkerRates(cli.Out(), rates)
+			}
+		}
+
+		if !opts.Watch {
+			return nil
+		}
+	}
+}
+
+func interfaceRatesLess(key string) (func(a, b vpp.InterfaceRates) bool, error) {
+	switch key {
+	case "name", "":
+		return func(a, b vpp.InterfaceRates) bool { return a.Interface < b.Interface }, nil
+	case "rx-pps":
+		return func(a, b vpp.InterfaceRates) bool { return a.RxPps > b.RxPps }, nil
+	case "tx-pps":
+		return func(a, b vpp.InterfaceRates) bool { return a.TxPps > b.TxPps }, nil
+	case "rx-bps":
+		return func(a, b vpp.InterfaceRates) bool { return a.RxBps > b.RxBps }, nil
+	case "tx-bps":
+		return func(a, b vpp.InterfaceRates) bool { return a.TxBps > b.TxBps }, nil
+	case "drops":
+		return func(a, b vpp.InterfaceRates) bool { return a.DropRate > b.DropRate }, nil
+	default:
+		return nil, fmt.Errorf("invalid sort key: %q", key)
+	}
+}
+
+func printStatsRates(out io.Writer, list []*InstanceRates) {
+	var buf bytes.Buffer
+
+	w := tabwriter.NewWriter(&buf, 0, 1, 2, ' ', tabwriter.StripEscape|tabwriter.FilterHTML)
+	fmt.Fprintln(w, "INSTANCE\tINTERFACE\tRX PPS\tRX BPS\tTX PPS\tTX BPS\tDROPS/s\tERRORS/s\t")
+	for _, r := range list {
+		for _, iface := range r.Interfaces {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n",
+				r.Instance,
+				colorize(interfaceColor, iface.Interface),
+				formatRate(iface.RxPps, ""),
+				formatRate(iface.RxBps, "b"),
+				formatRate(iface.TxPps, ""),
+				formatRate(iface.TxBps, "b"),
+				formatRate(iface.DropRate, ""),
+				formatRate(iface.RxErrorRate+iface.TxErrorRate, ""),
+			)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		logrus.Warnf("flushing table failed: %v", err)
+	}
+
+	for _, r := range list {
+		if len(r.Errors) == 0 {
+			continue
+		}
+		fmt.Fprintf(&buf, "\nError counters (%s):\n", r.Instance)
+		names := make([]string, 0, len(r.Errors))
+		for name := range r.Errors {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			return r.Errors[names[i]] > r.Errors[names[j]]
+		})
+		for _, name := range names {
+			fmt.Fprintf(&buf, "  %10s/s  %s\n", formatRate(r.Errors[name], ""), name)
+		}
+	}
+
+	fmt.Fprint(out, renderColor(buf.String()))
+}
+
+func printPerWorkerRates(out io.Writer, list []*InstanceRates) {
+	var buf bytes.Buffer
+
+	format := func(v float64) string {
+		return formatRate(v, "")
+	}
+	for _, r := range list {
+		fmt.Fprintf(&buf, "\nError counters per worker (%s):\n", r.Instance)
+		printPerWorkerTable(strutil.IndentedWriter(&buf), "COUNTER", r.ErrorsPerWorker, format)
+		fmt.Fprintf(&buf, "\nNode vectors per worker (%s):\n", r.Instance)
+		if len(r.NodeVectorsPerWorker) == 0 {
+			fmt.Fprintln(&buf, colorize(nonAvailableColor, "  per-worker node stats unavailable without stats directory (e.g. over govpp proxy)"))
+			continue
+		}
+		printPerWorkerTable(strutil.IndentedWriter(&buf), "NODE", r.NodeVectorsPerWorker, format)
+	}
+
+	fmt.Fprint(out, renderColor(buf.String()))
+}
+
+func formatRate(v float64, unit string) string {
+	switch {
+	case v == 0:
+		return colorize(nonAvailableColor, "0")
+	case v >= 1e9:
+		return fmt.Sprintf("%.2fG%s", v/1e9, unit)
+	case v >= 1e6:
+		return fmt.Sprintf("%.2fM%s", v/1e6, unit)
+	case v >= 1e3:
+		return fmt.Sprintf("%.2fk%s", v/1e3, unit)
+	default:
+		return fmt.Sprintf("%.1f%s", v, unit)
+	}
+}
+
+type StatsGetOptions struct {
+	Patterns []string
+	Format   string
+}
+
+func NewStatsGetCmd(cli Cli) *cobra.Command {
+	var (
+		opts StatsGetOptions
+	)
+	cmd := &cobra.Command{
+		Use:   "get PATTERN...",
+		Short: "Get entries of stats segment matching patterns",
+		Long:  "Get entries of stats segment with names matching glob patterns (* matches any sequence of characters) with values per worker",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Patterns = args
+			return RunStatsGet(cli, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.Format, "format", "f", "", "Output format (json, yaml, go-template..)")
+	return cmd
+}
+
+// InstanceStatEntries are stats segment entries of an instance.
+type InstanceStatEntries struct {
+	Instance string
+	Entries  []api.StatEntry
+
+	handler probe.Handler
+}
+
+func RunStatsGet(cli Cli, opts StatsGetOptions) error {
+	if err := cli.Client().DiscoverInstances(cli.Queries()...); err != nil {
+		return err
+	}
+
+	var list []*InstanceStatEntries
+	for _, instance := range cli.Client().Instances() {
+		entries, err := instance.GetStats(opts.Patterns...)
+		if err != nil {
+			logrus.Warnf("getting stats for instance %v failed: %v", instance.ID(), err)
+			continue
+		}
+		for _, e := range entries {
+			if e.Aggregated {
+				logrus.Warnf("stats directory of instance %v is not available (govpp proxy), listing only known stats with node and interface counters summed across workers", instance.ID())
+				break
+			}
+		}
+		list = append(list, &InstanceStatEntries{
+			Instance: instance.ID(),
+			Entries:  entries,
+			handler:  instance.Handler(),
+		})
+	}
+
+	if opts.Format != "" {
+		return formatAsTemplate(cli.Out(), opts.Format, list)
+	}
+	for _, r := range list {
+		printInstanceHeader(cli.Out(), r.handler)
+		printStatEntries(cli.Out(), r.Entries)
+	}
+	return nil
+}
+
+func printStatEntries(out io.Writer, entries []api.StatEntry) {
+	var buf bytes.Buffer
+
+	if len(entries) == 0 {
+		fmt.Fprintf(&buf, "%s\n", colorize(nonAvailableColor, "no entries"))
+	}
+	for _, e := range entries {
+		typ := string(e.Type)
+		if e.Aggregated {
+			typ += ", summed across workers"
+		}
+		fmt.Fprintf(&buf, "%s %s\n", colorize(highlightColor, e.Name), colorize(nonAvailableColor, "("+typ+")"))
+		switch e.Type {
+		case api.StatScalar:
+			fmt.Fprintf(&buf, "  %v\n", colorize(valueColor, *e.Scalar))
+		case api.StatError:
+			var total uint64
+			for _, v := range e.Errors {
+				total += v
+			}
+			fmt.Fprintf(&buf, "  %v %s\n", colorize(valueColor, total), formatPerWorker(e.Errors))
+		case api.StatCounter:
+			n := maxIndexes(len(e.Counters), func(w int) int { return len(e.Counters[w]) })
+			for i := 0; i < n; i++ {
+				values := make([]uint64, len(e.Counters))
+				var total uint64
+				for w := range e.Counters {
+					if i < len(e.Counters[w]) {
+						values[w] = e.Counters[w][i]
+						total += values[w]
+					}
+				}
+				fmt.Fprintf(&buf, "  [%d] %v %s\n", i, colorize(valueColor, total), formatPerWorker(values))
+			}
+		case api.StatCombined:
+			n := maxIndexes(len(e.Combined), func(w int) int { return len(e.Combined[w]) })
+			for i := 0; i < n; i++ {
+				var packets, octets uint64
+				for w := range e.Combined {
+					if i < len(e.Combined[w]) {
+						packets += e.Combined[w][i].Packets
+						octets += e.Combined[w][i].Bytes
+					}
+				}
+				fmt.Fprintf(&buf, "  [%d] %v packets, %v bytes\n", i, colorize(valueColor, packets), colorize(valueColor, octets))
+			}
+		case api.StatName:
+			for i, name := range e.Names {
+				if name != "" {
+					fmt.Fprintf(&buf, "  [%d] %s\n", i, name)
+				}
+			}
+		}
+	}
+	fmt.Fprintln(&buf)
+
+	fmt.Fprint(out, renderColor(buf.String()))
+}
+
+// maxIndexes returns the largest number of indexes among workers.
+func maxIndexes(workers int, length func(w int) int) int {
+	var n int
+	for w := 0; w < workers; w++ {
+		if l := length(w); l > n {
+			n = l
+		}
+	}
+	return n
+}
+
+func formatPerWorker(values []uint64) string {
+	if len(values) <= 1 {
+		return ""
+	}
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprint(v)
+	}
+	return colorize(nonAvailableColor, "(per worker: "+strings.Join(parts, " ")+")")
+}
@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -20,6 +24,34 @@ const topologyExample = `  # Print correlated connections from two clusters defi
   # Render correlated connections to image
   vpp-probe --kubeconfig="/path/to/kubeconfig1,/path/to/kubeconfig2" topology -f dot | dot -Tpng -o graph.png
   NOTE: Graphviz must be installed (apt install graphviz)
+
+  # Print correlated connections as Mermaid flowchart for Markdown
+  vpp-probe topology -f mermaid
+
+  # Export correlated connections for yEd or Gephi
+  vpp-probe topology -f graphml > topology.graphml
+
+  # Print all paths between two interfaces
+  vpp-probe topology vpp1/memif1 vpp2/tap0
+
+  # Render paths between two interfaces to image
+  vpp-probe topology vpp1/memif1 vpp2/tap0 -f dot | dot -Tpng -o path.png
+
+  # Save topology snapshot to file
+  vpp-probe topology --save topo.json
+
+  # Check topology for dangling peers, MTU and state mismatches
+  vpp-probe topology --validate
+`
+
+const topologyDiffExample = `  # Compare two saved topology snapshots
+  vpp-probe topology diff before.json after.json
+
+  # Compare saved topology snapshot against the live system
+  vpp-probe topology diff before.json
+
+  # Render topology changes to image
+  vpp-probe topology diff before.json after.json -f dot | dot -Tpng -o diff.png
 `
 
 func NewTopologyCmd(cli Cli) *cobra.Command {
@@ -27,9 +59,11 @@ func NewTopologyCmd(cli Cli) *cobra.Command {
 		opts TopologyOptions
 	)
 	cmd := &cobra.Command{
-		Use:     "topology [options]",
+		Use:     "topology [options] [SRC DST]",
 		Aliases: []string{"topo"},
 		Short:   "Correlate connections from VPP instances into topology",
+		Long:    "Correlate connections from VPP instances into topology. If source and destination endpoints are given in format INSTANCE/INTERFACE, all paths between them are printed.",
+		Args:    cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) >= 1 {
 				opts.Src = args[0]
@@ -42,20 +76,91 @@ func NewTopologyCmd(cli Cli) *cobra.Command {
 		Example: topologyExample,
 	}
 	flags := cmd.Flags()
-	flags.StringVarP(&opts.Format, "format", "f", "", "Output format (json, yaml, go-template..)")
+	flags.StringVarP(&opts.Format, "format", "f", "", "Output format (dot, mermaid, d2, graphml, cyjs, json, yaml, go-template..)")
+	flags.IntVar(&opts.MaxDepth, "max-depth", topology.DefaultMaxPathDepth, "Maximum number of hops of paths between endpoints")
+	flags.StringVar(&opts.Save, "save", "", "Save topology snapshot to file")
+	flags.BoolVar(&opts.Validate, "validate", false, "Validate topology and report findings (exits with error if any errors are found)")
+	cmd.AddCommand(NewTopologyDiffCmd(cli))
 	return cmd
 }
 
 type TopologyOptions struct {
-	Format string
-	Src    string
-	Dst    string
+	Format   string
+	Src      string
+	Dst      string
+	MaxDepth int
+	Save     string
+	Validate bool
 }
 
 func RunTopology(cli Cli, opts TopologyOptions) error {
-	if err := cli.Client().DiscoverInstances(cli.Queries()...); err != nil {
+	if (opts.Src == "") != (opts.Dst == "") {
+		return fmt.Errorf("both source and destination endpoints are required")
+	}
+	instances, topo, err := buildTopology(cli)
+	if err != nil {
 		return err
 	}
+
+	if opts.Save != "" {
+		if err := topology.SaveSnapshot(opts.Save, topology.NewSnapshot(instances, topo)); err != nil {
+			return err
+		}
+		logrus.Infof("topology snapshot saved to %s", opts.Save)
+	}
+
+	if opts.Validate {
+		findings := topology.Validate(topo)
+		if format := opts.Format; len(format) == 0 {
+			printTopologyFindings(cli.Out(), findings)
+		} else if err := formatAsTemplate(cli.Out(), format, findings); err != nil {
+			return err
+		}
+		if topology.HasErrors(findings) {
+			return fmt.Errorf("topology validation failed")
+		}
+		return nil
+	}
+
+	if opts.Src != "" {
+		paths, err := topology.FindPaths(topo, opts.Src, opts.Dst, opts.MaxDepth)
+		if err != nil {
+			return err
+		}
+		if format := opts.Format; len(format) == 0 {
+			printTopologyPaths(cli.Out(), opts, paths)
+		} else if format == "dot" {
+			return topology.PrintPathsDot(cli.Out(), instances, topo, paths)
+		} else {
+			return formatAsTemplate(cli.Out(), format, paths)
+		}
+		return nil
+	}
+
+	if format := opts.Format; len(format) == 0 {
+		printTopologyTable(cli.Out(), instances, topo)
+	} else if format == "dot" {
+		return topology.PrintTopologyDot(cli.Out(), instances, topo)
+	} else if format == "mermaid" {
+		return topology.PrintTopologyMermaid(cli.Out(), instances, topo)
+	} else if format == "d2" {
+		return topology.PrintTopologyD2(cli.Out(), instances, topo)
+	} else if format == "graphml" {
+		return topology.PrintTopologyGraphML(cli.Out(), instances, topo)
+	} else if format == "cyjs" {
+		return topology.PrintTopologyCytoscape(cli.Out(), instances, topo)
+	} else {
+		return formatAsTemplate(cli.Out(), format, topo)
+	}
+
+	return nil
+}
+
+// buildTopology discovers instances and correlates their connections.
+func buildTopology(cli Cli) ([]*vpp.Instance, *topology.Info, error) {
+	if err := cli.Client().DiscoverInstances(cli.Queries()...); err != nil {
+		return nil, nil, err
+	}
 	instances := cli.Client().Instances()
 
 	logrus.Infof("discovered %d vpp instances", len(instances))
@@ -63,13 +168,9 @@ func RunTopology(cli Cli, opts TopologyOptions) error {
 	var errs []error
 
 	for _, instance := range instances {
-		if instance.Agent() == nil {
-			logrus.Debugf("agent not found for instance %v", instance.ID())
-			continue
-		}
 		logrus.Debugf("- updating vpp info %+v: %v", instance.ID(), instance.Status())
 
-		err := instance.Agent().UpdateInstanceInfo()
+		err := instance.UpdateConfig()
 		if err != nil {
 			errs = append(errs, err)
 			logrus.Errorf("instance %v error: %v", instance.ID(), err)
@@ -78,25 +179,17 @@ func RunTopology(cli Cli, opts TopologyOptions) error {
 	}
 
 	if len(errs) > 0 {
-		return fmt.Errorf("cannot build topology, %d/%d instances failed to update info", len(errs), len(instances))
+		return nil, nil, fmt.Errorf("cannot build topology, %d/%d instances failed to update info", len(errs), len(instances))
 	}
 
 	topo, err := topology.Build(instances)
 	if err != nil {
-		return fmt.Errorf("correlation failed: %w", err)
+		return nil, nil, fmt.Errorf("correlation failed: %w", err)
 	}
 
 	logrus.Infof("correlated %v connections for %d instances", len(topo.Connections), len(instances))
 
-	if format := opts.Format; len(format) == 0 {
-		printTopologyTable(cli.Out(), instances, topo)
-	} else if format == "dot" {
-		return topology.PrintTopologyDot(cli.Out(), instances, topo)
-	} else {
-		return formatAsTemplate(cli.Out(), format, topo)
-	}
-
-	return nil
+	return instances, topo, nil
 }
 
 func printTopologyTable(w io.Writer, instances []*vpp.Instance, topop *topology.Info) {
@@ -107,9 +200,7 @@ func printTopologyTable(w io.Writer, instances []*vpp.Instance, topop *topology.
 
 	if len(topop.Networks) > 0 {
 		fmt.Fprintf(w, "Networks (%d)\n", len(topop.Networks))
-		for _, network := range topop.Networks {
-			fmt.Fprintf(w, " - %+v", network)
-		}
+		printTopologyNetworks(w, topop.Networks)
 	}
 
 	fmt.Fprintf(w, "Connections (%d)\n", len(topop.Connections))
@@ -117,3 +208,252 @@ func printTopologyTable(w io.Writer, instances []*vpp.Instance, topop *topology.
 		fmt.Fprintf(w, " - %+v <=> %+v\n", conn.Source, conn.Destination)
 	}
 }
+
+// printTopologyNetworks prints endpoints grouped by instance and network.
+func printTopologyNetworks(out io.Writer, networks []topology.NetworkInfo) {
+	var instanceIDs []string
+	byInstance := map[string][]topology.NetworkInfo{}
+	for _, network := range networks {
+		if _, ok := byInstance[network.Instance]; !ok {
+			instanceIDs = append(instanceIDs, network.Instance)
+		}
+		byInstance[network.Instance] = append(byInstance[network.Instance], network)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 1, 2, ' ', tabwriter.StripEscape|tabwriter.FilterHTML)
+	for _, id := range instanceIDs {
+		list := byInstance[id]
+		var location []string
+		if c := list[0].Cluster; c != "" {
+			location = append(location, "cluster "+c)
+		}
+		if n := list[0].Node; n != "" {
+			location = append(location, "node "+n)
+		}
+		name := strings.TrimPrefix(id, "instance::")
+		if len(location) > 0 {
+			name += fmt.Sprintf(" (%s)", strings.Join(location, ", "))
+		}
+		fmt.Fprintf(w, " %s\n", name)
+		for _, network := range list {
+			fmt.Fprintf(w, "   %s\n", network.Label())
+			for _, e := range network.Endpoints {
+				fmt.Fprintf(w, "   - %s\t%s\t%s\t%s\t\n", e.Interface,
+					orDash(e.Metadata["internal"]), orDash(e.Metadata["state"]), orDash(e.Metadata["ips"]))
+			}
+		}
+	}
+	if err := w.Flush(); err != nil {
+		logrus.Warnf("flushing table failed: %v", err)
+	}
+}
+
+func printTopologyFindings(out io.Writer, findings []topology.Finding) {
+	var buf bytes.Buffer
+
+	if len(findings) == 0 {
+		fmt.Fprintf(&buf, "%s\n", colorize(statusUpColor, "no problems found"))
+		fmt.Fprint(out, renderColor(buf.String()))
+		return
+	}
+
+	var errs int
+	w := tabwriter.NewWriter(&buf, 0, 1, 2, ' ', tabwriter.StripEscape|tabwriter.FilterHTML)
+	fmt.Fprintln(w, "SEVERITY\tRULE\tSUBJECT\tMESSAGE\t")
+	for _, f := range findings {
+		severityColor := noteColor
+		if f.Severity == topology.SeverityError {
+			severityColor = statusDownColor
+			errs++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n",
+			colorize(severityColor, string(f.Severity)),
+			f.Rule,
+			colorize(interfaceColor, f.Subject),
+			f.Message,
+		)
+	}
+	if err := w.Flush(); err != nil {
+		logrus.Warnf("flushing table failed: %v", err)
+	}
+	fmt.Fprintf(&buf, "\n%d errors, %d warnings\n", errs, len(findings)-errs)
+
+	fmt.Fprint(out, renderColor(buf.String()))
+}
+
+func printTopologyPaths(out io.Writer, opts TopologyOptions, paths []topology.Path) {
+	var buf bytes.Buffer
+
+	if len(paths) == 0 {
+		fmt.Fprintf(&buf, "%s\n", colorize(statusDownColor, fmt.Sprintf("no path found between %s and %s (max depth %d)", opts.Src, opts.Dst, opts.MaxDepth)))
+		fmt.Fprint(out, renderColor(buf.String()))
+		return
+	}
+
+	for i, path := range paths {
+		pathColor := statusUpColor
+		if path.IsDown() {
+			pathColor = statusDownColor
+		}
+		fmt.Fprintf(&buf, "Path %d: %s\n", i+1, colorize(pathColor, fmt.Sprintf("%d hops", len(path.Hops))))
+
+		w := tabwriter.NewWriter(&buf, 0, 1, 2, ' ', tabwriter.StripEscape|tabwriter.FilterHTML)
+		for j, hop := range path.Hops {
+			stateColor := statusUpColor
+			if hop.IsDown() {
+				stateColor = statusDownColor
+			}
+			fmt.Fprintf(w, " %d.\t%s\t-> %s\t%s\t%s\t\n", j+1,
+				colorize(interfaceColor, formatPathEndpoint(hop.From)),
+				colorize(interfaceColor, formatPathEndpoint(hop.To)),
+				colorize(noteColor, hop.Type),
+				colorize(stateColor, hop.State),
+			)
+		}
+		if err := w.Flush(); err != nil {
+			logrus.Warnf("flushing table failed: %v", err)
+		}
+		fmt.Fprintln(&buf)
+	}
+
+	fmt.Fprint(out, renderColor(buf.String()))
+}
+
+func formatPathEndpoint(e topology.Endpoint) string {
+	name := fmt.Sprintf("%s/%s", strings.TrimPrefix(e.Instance, "instance::"), e.Interface)
+	if e.Type == topology.KernelNetwork {
+		name += " (linux"
+		if e.Namespace != "" {
+			name += " ns " + e.Namespace
+		}
+		name += ")"
+	}
+	return name
+}
+
+func NewTopologyDiffCmd(cli Cli) *cobra.Command {
+	var (
+		opts TopologyDiffOptions
+	)
+	cmd := &cobra.Command{
+		Use:   "diff [options] BEFORE [AFTER]",
+		Short: "Compare topology snapshots",
+		Long:  "Compare topology snapshot saved with --save against another snapshot or against the live system if AFTER is omitted.",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Before = args[0]
+			if len(args) >= 2 {
+				opts.After = args[1]
+			}
+			return RunTopologyDiff(cli, opts)
+		},
+		Example: topologyDiffExample,
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.Format, "format", "f", "", "Output format (dot, json, yaml, go-template..)")
+	return cmd
+}
+
+type TopologyDiffOptions struct {
+	Format string
+	Before string
+	After  string
+}
+
+func RunTopologyDiff(cli Cli, opts TopologyDiffOptions) error {
+	before, err := topology.LoadSnapshot(opts.Before)
+	if err != nil {
+		return err
+	}
+
+	var after *topology.Snapshot
+	if opts.After != "" {
+		after, err = topology.LoadSnapshot(opts.After)
+		if err != nil {
+			return err
+		}
+	} else {
+		instances, topo, err := buildTopology(cli)
+		if err != nil {
+			return err
+		}
+		after = topology.NewSnapshot(instances, topo)
+	}
+
+	diff := topology.DiffSnapshots(before, after)
+
+	if format := opts.Format; len(format) == 0 {
+		printTopologyDiff(cli.Out(), before, after, diff)
+	} else if format == "dot" {
+		return topology.PrintDiffDot(cli.Out(), diff)
+	} else {
+		return formatAsTemplate(cli.Out(), format, diff)
+	}
+	return nil
+}
+
+func printTopologyDiff(out io.Writer, before, after *topology.Snapshot, diff *topology.Diff) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "Comparing topology from %v with %v\n\n",
+		colorize(noteColor, before.Created.Format(time.RFC3339)), colorize(noteColor, after.Created.Format(time.RFC3339)))
+
+	if diff.IsEmpty() {
+		fmt.Fprintf(&buf, "%s\n", colorize(statusUpColor, "no changes"))
+		fmt.Fprint(out, renderColor(buf.String()))
+		return
+	}
+
+	if len(diff.AddedInstances) > 0 || len(diff.RemovedInstances) > 0 {
+		fmt.Fprintf(&buf, "Instances\n")
+		for _, id := range diff.AddedInstances {
+			fmt.Fprintf(&buf, " %s\n", colorize(statusUpColor, "+ "+id))
+		}
+		for _, id := range diff.RemovedInstances {
+			fmt.Fprintf(&buf, " %s\n", colorize(statusDownColor, "- "+id))
+		}
+		fmt.Fprintln(&buf)
+	}
+
+	if len(diff.AddedConnections) > 0 || len(diff.RemovedConnections) > 0 {
+		fmt.Fprintf(&buf, "Connections\n")
+		w := tabwriter.NewWriter(&buf, 0, 1, 2, ' ', tabwriter.StripEscape|tabwriter.FilterHTML)
+		for _, conn := range diff.AddedConnections {
+			fmt.Fprintf(w, " %s\t%s\t-> %s\t%s\t\n", colorize(statusUpColor, "+"),
+				colorize(interfaceColor, formatPathEndpoint(conn.Source)),
+				colorize(interfaceColor, formatPathEndpoint(conn.Destination)),
+				colorize(noteColor, conn.Metadata["type"]))
+		}
+		for _, conn := range diff.RemovedConnections {
+			fmt.Fprintf(w, " %s\t%s\t-> %s\t%s\t\n", colorize(statusDownColor, "-"),
+				colorize(interfaceColor, formatPathEndpoint(conn.Source)),
+				colorize(interfaceColor, formatPathEndpoint(conn.Destination)),
+				colorize(noteColor, conn.Metadata["type"]))
+		}
+		if err := w.Flush(); err != nil {
+			logrus.Warnf("flushing table failed: %v", err)
+		}
+		fmt.Fprintln(&buf)
+	}
+
+	if len(diff.StateChanges) > 0 {
+		fmt.Fprintf(&buf, "State changes\n")
+		w := tabwriter.NewWriter(&buf, 0, 1, 2, ' ', tabwriter.StripEscape|tabwriter.FilterHTML)
+		for _, change := range diff.StateChanges {
+			afterColor := statusUpColor
+			if strings.Contains(change.After, "down") {
+				afterColor = statusDownColor
+			}
+			fmt.Fprintf(w, " %s\t%s\t-> %s\t\n",
+				colorize(interfaceColor, change.Name),
+				change.Before,
+				colorize(afterColor, change.After))
+		}
+		if err := w.Flush(); err != nil {
+			logrus.Warnf("flushing table failed: %v", err)
+		}
+		fmt.Fprintln(&buf)
+	}
+
+	fmt.Fprint(out, renderColor(buf.String()))
+}
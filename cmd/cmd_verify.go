@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"go.ligato.io/vpp-probe/client"
+	"go.ligato.io/vpp-probe/probe"
+	"go.ligato.io/vpp-probe/vpp"
+	"go.ligato.io/vpp-probe/vpp/agent"
+)
+
+const verifyExample = `  # Verify agent config against VPP state in Kubernetes
+  vpp-probe verify -e kube
+
+  # Verify agent config against VPP state in JSON
+  vpp-probe verify -e kube -f json`
+
+type VerifyOptions struct {
+	Format string
+}
+
+func NewVerifyCmd(cli Cli) *cobra.Command {
+	var (
+		opts VerifyOptions
+	)
+	cmd := &cobra.Command{
+		Use:     "verify [options]",
+		Short:   "Verify agent config against VPP state",
+		Long:    "Compare VPP interfaces configured by agent with interfaces in VPP and report mismatches of admin state, IP addresses, VRF, MTU, MAC and interfaces not managed by agent",
+		Example: verifyExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunVerify(cli, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.Format, "format", "f", "", "Output format (json, yaml, go-template..)")
+	return cmd
+}
+
+// InstanceVerify is result of config verification of an instance.
+type InstanceVerify struct {
+	Instance   string
+	Mismatches []vpp.ConfigMismatch
+	Skipped    string `json:",omitempty"`
+
+	handler probe.Handler
+}
+
+func RunVerify(cli Cli, opts VerifyOptions) error {
+	if err := cli.Client().DiscoverInstances(cli.Queries()...); err != nil {
+		return err
+	}
+	instances, skipped, err := agentInstances(cli.Client().Instances())
+	if err != nil {
+		return err
+	}
+
+	resultch := make(chan *InstanceVerify, len(instances))
+
+	if err := client.RunOnInstances(instances, func(instance *vpp.Instance) error {
+		config, err := agent.RetrieveCachedConfig(instance.Agent().Client())
+		if err != nil {
+			return fmt.Errorf("instance %v error: %w", instance.ID(), err)
+		}
+		ifaces, err := instance.ListInterfaces()
+		if err != nil {
+			return fmt.Errorf("instance %v error: %w", instance.ID(), err)
+		}
+		resultch <- &InstanceVerify{
+			Instance:   instance.ID(),
+			Mismatches: vpp.VerifyInterfaces(config.VPP.Interfaces, ifaces),
+			handler:    instance.Handler(),
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	close(resultch)
+
+	var list []*InstanceVerify
+	for r := range resultch {
+		list = append(list, r)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Instance < list[j].Instance
+	})
+
+	if opts.Format != "" {
+		for _, instance := range skipped {
+			list = append(list, &InstanceVerify{
+				Instance: instance.ID(),
+				Skipped:  "agent not found",
+			})
+		}
+		return formatAsTemplate(cli.Out(), opts.Format, list)
+	}
+	for _, r := range list {
+		printInstanceVerify(cli.Out(), r)
+	}
+	printSkippedInstances(cli.Out(), skipped)
+	return nil
+}
+
+func printInstanceVerify(out io.Writer, r *InstanceVerify) {
+	var buf bytes.Buffer
+
+	printInstanceHeader(&buf, r.handler)
+
+	if len(r.Mismatches) == 0 {
+		fmt.Fprintf(&buf, "%s\n\n", colorize(statusUpColor, "config consistent"))
+		fmt.Fprint(out, renderColor(buf.String()))
+		return
+	}
+
+	w := tabwriter.NewWriter(&buf, 0, 1, 2, ' ', tabwriter.StripEscape|tabwriter.FilterHTML)
+	fmt.Fprintln(w, "INTERFACE\tVPP\tMISMATCH\tEXPECTED\tACTUAL\t")
+	for _, m := range r.Mismatches {
+		fieldColor := statusDownColor
+		if m.Field == vpp.MismatchUnmanaged {
+			fieldColor = noteColor
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t\n",
+			colorize(interfaceColor, orDash(m.Interface)),
+			colorize(highlightColor, orDash(m.VppName)),
+			colorize(fieldColor, string(m.Field)),
+			colorize(valueColor, m.Expected),
+			colorize(valueColor, m.Actual),
+		)
+	}
+	if err := w.Flush(); err != nil {
+		logrus.Warnf("flushing table failed: %v", err)
+	}
+	fmt.Fprintln(&buf)
+
+	fmt.Fprint(out, renderColor(buf.String()))
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
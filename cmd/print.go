@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"sort"
 	"strings"
 	"text/tabwriter"
 
@@ -13,7 +14,9 @@ import (
 	vpp_interfaces "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/interfaces"
 	"google.golang.org/protobuf/reflect/protoreflect"
 
+	"go.ligato.io/vpp-probe/pkg/strutil"
 	"go.ligato.io/vpp-probe/vpp"
+	"go.ligato.io/vpp-probe/vpp/api"
 
 	"go.ligato.io/vpp-probe/probe"
 	"go.ligato.io/vpp-probe/providers"
@@ -113,7 +116,7 @@ func PrintVPPInterfacesTable(out io.Writer, instance *vpp.Instance) {
 	}
 	fmt.Fprintln(w, strings.Join(header, "\t"))
 
-	config := instance.Agent().Config
+	config := instance.Config()
 
 	for _, v := range config.VPP.Interfaces {
 		if v.Metadata["InternalName"] == defaultVppInterfaceName {
@@ -168,7 +171,7 @@ func PrintLinuxInterfacesTable(out io.Writer, instance *vpp.Instance) {
 	}
 	fmt.Fprintln(w, strings.Join(header, "\t"))
 
-	config := instance.Agent().Config
+	config := instance.Config()
 
 	for _, v := range config.Linux.Interfaces {
 		iface := v.Value
@@ -210,6 +213,15 @@ func PrintCorrelatedIpSec(out io.Writer, correlations *agent.IPSecCorrelations)
 	}
 	fmt.Fprintln(w, strings.Join(header, "\t"))
 
+	// findSA returns SA of SP configured on instance with IP
+	findSA := func(ip string, saIdx uint32) *agent.VppIPSecSA {
+		instance := correlations.SrcInstanceMap[ip]
+		if instance == nil || instance.Config == nil {
+			return nil
+		}
+		return agent.FindIPSecSA(saIdx, instance.Config.VPP.IPSecSAs)
+	}
+
 	// correlate data
 	for inSrcIp, inSpMap := range correlations.InSpSrcDestMap {
 		for inDestIp, inSp := range inSpMap {
@@ -223,10 +235,10 @@ func PrintCorrelatedIpSec(out io.Writer, correlations *agent.IPSecCorrelations)
 					saMatch = "Y"
 				}
 
-				inSa := agent.FindIPSecSA(inSp.Value.SaIndex, correlations.SrcInstanceMap[inSrcIp].Config.VPP.IPSecSAs)
+				inSa := findSA(inSrcIp, inSp.Value.SaIndex)
 				if inSa != nil {
 					paramsMatch := 0
-					if outSa := agent.FindIPSecSA(inSp.Value.SaIndex, correlations.SrcInstanceMap[inDestIp].Config.VPP.IPSecSAs); outSa != nil {
+					if outSa := findSA(inDestIp, inSp.Value.SaIndex); outSa != nil {
 						if inSa.Value.Spi == outSa.Value.Spi {
 							paramsMatch |= 0x1
 						}
@@ -239,7 +251,7 @@ func PrintCorrelatedIpSec(out io.Writer, correlations *agent.IPSecCorrelations)
 					}
 
 					cols := []string{
-						srcdestIp, "Y", fmt.Sprint(inSp.Value.SaIndex), saMatch, fmt.Sprint(inSa.Value.Spi), inSa.Value.CryptoKey, inSa.Value.IntegKey, fmt.Sprintf("%03b\n", paramsMatch),
+						srcdestIp, "Y", fmt.Sprint(inSp.Value.SaIndex), saMatch, fmt.Sprint(inSa.Value.Spi), inSa.Value.CryptoAlg.String(), inSa.Value.IntegAlg.String(), fmt.Sprintf("%03b\n", paramsMatch),
 					}
 					fmt.Fprintln(w, strings.Join(cols, "\t"))
 				} else {
@@ -267,26 +279,23 @@ func PrintCorrelatedIpSec(out io.Writer, correlations *agent.IPSecCorrelations)
 	}
 	fmt.Fprintln(w, strings.Join(header, "\t"))
 
+	printSP := func(spi uint32, dir string, sp agent.VppIPSecSP) {
+		inSrcIp := sp.Value.LocalAddrStart
+		cols := []string{
+			fmt.Sprintf("0x%x", spi), dir, fmt.Sprintf("%s<->%s", inSrcIp, sp.Value.RemoteAddrStart),
+			fmt.Sprint(sp.Value.SaIndex),
+		}
+		if sa := findSA(inSrcIp, sp.Value.SaIndex); sa != nil {
+			cols = append(cols, sa.Value.CryptoAlg.String(), sa.Value.IntegAlg.String())
+		}
+		fmt.Fprintln(w, strings.Join(cols, "\t"))
+	}
 	for spi, spList := range correlations.SpiOutSrcDestMap {
 		for _, sp := range spList {
-			inSrcIp := sp.Value.LocalAddrStart
-			inSa := agent.FindIPSecSA(sp.Value.SaIndex, correlations.SrcInstanceMap[inSrcIp].Config.VPP.IPSecSAs)
-			cols := []string{
-				fmt.Sprintf("0x%x", spi), "Out", fmt.Sprintf("%s<->%s", inSrcIp, sp.Value.RemoteAddrStart),
-				fmt.Sprint(sp.Value.SaIndex), inSa.Value.CryptoKey, inSa.Value.IntegKey,
-			}
-			fmt.Fprintln(w, strings.Join(cols, "\t"))
+			printSP(spi, "Out", sp)
 		}
-		if _, ok := correlations.SpiInSrcDestMap[spi]; ok {
-			for _, sp := range correlations.SpiInSrcDestMap[spi] {
-				inSrcIp := sp.Value.LocalAddrStart
-				inSa := agent.FindIPSecSA(sp.Value.SaIndex, correlations.SrcInstanceMap[inSrcIp].Config.VPP.IPSecSAs)
-				cols := []string{
-					fmt.Sprintf("0x%x", spi), "In", fmt.Sprintf("%s<->%s", inSrcIp, sp.Value.RemoteAddrStart),
-					fmt.Sprint(sp.Value.SaIndex), inSa.Value.CryptoKey, inSa.Value.IntegKey,
-				}
-				fmt.Fprintln(w, strings.Join(cols, "\t"))
-			}
+		for _, sp := range correlations.SpiInSrcDestMap[spi] {
+			printSP(spi, "In", sp)
 		}
 	}
 
@@ -296,6 +305,52 @@ func PrintCorrelatedIpSec(out io.Writer, correlations *agent.IPSecCorrelations)
 	}
 
 	fmt.Fprint(out, buf.String())
+
+	if correlations.Pairing != nil {
+		fmt.Fprintln(out)
+		PrintIPSecPairing(out, correlations.Pairing)
+	}
+}
+
+// PrintIPSecPairing prints tunnels paired by SPI with verdict and issues.
+func PrintIPSecPairing(out io.Writer, pairing *agent.IPSecPairing) {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 1, 2, ' ', tabwriter.StripEscape|tabwriter.FilterHTML)
+
+	formatEnd := func(end *agent.IPSecSAEnd) string {
+		if end == nil {
+			return colorize(nonAvailableColor, "-")
+		}
+		return fmt.Sprintf("%s SA %d (%s -> %s)", colorize(highlightColor, end.Instance), end.SaIndex, end.TunnelSrc, end.TunnelDst)
+	}
+
+	fmt.Fprintln(w, strings.Join([]string{
+		colorize(color.Bold, "SPI"), colorize(color.Bold, "Outbound"), colorize(color.Bold, "Inbound"), colorize(color.Bold, "Verdict"),
+	}, "\t")+"\t")
+	for _, t := range pairing.Tunnels {
+		verdictColor := statusUpColor
+		if t.Verdict != agent.IPSecOK {
+			verdictColor = statusDownColor
+		}
+		fmt.Fprintf(w, "0x%x\t%s\t%s\t%s\t\n", t.Spi, formatEnd(t.Out), formatEnd(t.In), colorize(verdictColor, string(t.Verdict)))
+		for _, issue := range t.Issues {
+			fmt.Fprintf(w, "\t%s\t\t\t\n", colorize(noteColor, "- "+issue))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, p := range pairing.Policies {
+		dir := "inbound"
+		if p.Outbound {
+			dir = "outbound"
+		}
+		fmt.Fprintf(&buf, "%s SPD %d %s policy %s <-> %s: %s\n", colorize(highlightColor, p.Instance), p.SpdIndex, dir, p.Local, p.Remote, colorize(statusDownColor, p.Issue))
+	}
+
+	fmt.Fprint(out, buf.String())
 }
 
 func linuxInterfaceType(iface agent.LinuxInterface) string {
@@ -527,3 +582,106 @@ func interfaceLinkInfo(ifaceMsg protoreflect.Message) string {
 	m := protoFieldsToMap(d.Fields(), link)
 	return mapValuesColorized(m, valueColor)
 }
+
+// printPerWorkerStats prints per-worker values of error counters and node vectors.
+func printPerWorkerStats(out io.Writer, stats *api.VppStats) {
+	if stats == nil {
+		return
+	}
+	counters := make(map[string][]float64, len(stats.CountersPerWorker))
+	for name, values := range stats.CountersPerWorker {
+		counters[name] = vpp.Uint64sToFloats(values)
+	}
+	nodes := make(map[string][]float64, len(stats.NodesPerWorker))
+	for name, list := range stats.NodesPerWorker {
+		values := make([]float64, len(list))
+		for w, n := range list {
+			values[w] = float64(n.Vectors)
+		}
+		nodes[name] = values
+	}
+	formatCount := func(v float64) string {
+		if v == 0 {
+			return colorize(nonAvailableColor, "0")
+		}
+		return fmt.Sprint(uint64(v))
+	}
+
+	fmt.Fprintln(out, colorize(headerColor, "Error counters per worker"))
+	printPerWorkerTable(strutil.IndentedWriter(out), "COUNTER", counters, formatCount)
+	fmt.Fprintln(out)
+
+	fmt.Fprintln(out, colorize(headerColor, "Node vectors per worker"))
+	if len(nodes) == 0 {
+		fmt.Fprintln(out, colorize(nonAvailableColor, "  per-worker node stats unavailable without stats directory (e.g. over govpp proxy)"))
+	} else {
+		printPerWorkerTable(strutil.IndentedWriter(out), "NODE", nodes, formatCount)
+	}
+	fmt.Fprintln(out)
+}
+
+// printPerWorkerTable prints rows of per-worker values sorted by total with
+// column for each thread and highlighted skew.
+func printPerWorkerTable(out io.Writer, nameHeader string, rows map[string][]float64, format func(float64) string) {
+	if len(rows) == 0 {
+		fmt.Fprintln(out, colorize(nonAvailableColor, "none"))
+		return
+	}
+
+	var workers int
+	totals := make(map[string]float64, len(rows))
+	names := make([]string, 0, len(rows))
+	for name, values := range rows {
+		names = append(names, name)
+		for _, v := range values {
+			totals[name] += v
+		}
+		if len(values) > workers {
+			workers = len(values)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if totals[names[i]] != totals[names[j]] {
+			return totals[names[i]] > totals[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	w := tabwriter.NewWriter(out, 0, 1, 2, ' ', tabwriter.StripEscape|tabwriter.FilterHTML)
+	header := []string{nameHeader, "TOTAL", "MAIN"}
+	for i := 1; i < workers; i++ {
+		header = append(header, fmt.Sprintf("W%d", i))
+	}
+	header = append(header, "SKEW")
+	fmt.Fprintf(w, "%s\t\n", strings.Join(header, "\t"))
+
+	for _, name := range names {
+		values := rows[name]
+		worker, share := vpp.WorkerSkew(values)
+		skewed := share > vpp.DefaultSkewThreshold
+
+		cols := []string{name, format(totals[name])}
+		for i := 0; i < workers; i++ {
+			var v float64
+			if i < len(values) {
+				v = values[i]
+			}
+			cell := format(v)
+			if skewed && i == worker {
+				cell = colorize(statusDownColor, cell)
+			}
+			cols = append(cols, cell)
+		}
+		skew := colorize(nonAvailableColor, "-")
+		if skewed {
+			skew = colorize(statusDownColor, fmt.Sprintf("W%d %.0f%%", worker, share*100))
+		} else if share > 0 {
+			skew = fmt.Sprintf("%.0f%%", share*100)
+		}
+		cols = append(cols, skew)
+		fmt.Fprintf(w, "%s\t\n", strings.Join(cols, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		log.Printf("flushing table failed: %v", err)
+	}
+}
@@ -23,6 +23,7 @@ require (
 	go.fd.io/govpp v0.7.0
 	go.ligato.io/cn-infra/v2 v2.5.0-alpha.0.20220610112835-012faf45555e
 	go.ligato.io/vpp-agent/v3 v3.5.0-alpha.0.20221208122858-ee3433b67005
+	golang.org/x/crypto v0.0.0-20220214200702-86341886e292
 	google.golang.org/protobuf v1.28.0
 	k8s.io/api v0.24.3
 	k8s.io/apimachinery v0.24.3
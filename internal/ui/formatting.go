@@ -45,6 +45,63 @@ func formatInterfaceMTU(mtu api.MTU) string {
 	return strings.Join(mtus, ", ")
 }
 
+func formatInterfaceLink(link api.Link) string {
+	var speed string
+	switch {
+	case link.Speed == 0:
+		return link.Duplex
+	case link.Speed%1000000 == 0:
+		speed = fmt.Sprintf("%dG", link.Speed/1000000)
+	case link.Speed%1000 == 0:
+		speed = fmt.Sprintf("%dM", link.Speed/1000)
+	default:
+		speed = fmt.Sprintf("%dK", link.Speed)
+	}
+	if link.Duplex != "" {
+		return fmt.Sprintf("%s %s", speed, link.Duplex)
+	}
+	return speed
+}
+
+func formatInterfaceQueues(iface *api.Interface) string {
+	if iface.RxQueues == 0 && iface.TxQueues == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", iface.RxQueues, iface.TxQueues)
+}
+
+func formatInterfaceDetails(iface *api.Interface) string {
+	var details []string
+	if sub := iface.Sub; sub != nil {
+		d := fmt.Sprintf("sub of %d", sub.Parent)
+		if sub.NumTags > 0 {
+			d += fmt.Sprintf(" vlan %d", sub.OuterVlan)
+		}
+		if sub.NumTags > 1 {
+			d += fmt.Sprintf("/%d", sub.InnerVlan)
+		}
+		details = append(details, d)
+	}
+	if bond := iface.Bond; bond != nil {
+		details = append(details, fmt.Sprintf("bond %s (%d/%d active)", bond.Mode, bond.ActiveMembers, len(bond.Members)))
+	}
+	if iface.BondedTo != nil {
+		details = append(details, fmt.Sprintf("member of %d", *iface.BondedTo))
+	}
+	if tun := iface.Tunnel; tun != nil {
+		details = append(details, fmt.Sprintf("%s %s -> %s", tun.Type, tun.Src, tun.Dst))
+	}
+	return strings.Join(details, ", ")
+}
+
+func formatInterfaceFeatures(arcs []api.FeatureArc) string {
+	var features []string
+	for _, arc := range arcs {
+		features = append(features, fmt.Sprintf("%s: %s", arc.Arc, strings.Join(arc.Features, " ")))
+	}
+	return strings.Join(features, ", ")
+}
+
 func formatInterfaceStatus(status api.Status) string {
 	var s string
 	color := "yellow"
@@ -111,3 +168,62 @@ func shortHumanDuration(d time.Duration) string {
 	}
 	return fmt.Sprintf("%d years", int(d.Hours()/24/365))
 }
+
+func formatPerWorkerStats(stats *api.VppStats) []string {
+	if stats == nil {
+		return nil
+	}
+	lines := []string{"Per-worker error counters:", "---------------"}
+	lines = append(lines, formatPerWorkerRows(stats.CountersPerWorker)...)
+	lines = append(lines, "", "Per-worker node vectors:", "---------------")
+	if len(stats.NodesPerWorker) == 0 {
+		lines = append(lines, "[gray]unavailable without stats directory (e.g. over govpp proxy)[-]")
+	}
+	nodes := make(map[string][]uint64, len(stats.NodesPerWorker))
+	for name, list := range stats.NodesPerWorker {
+		values := make([]uint64, len(list))
+		for w, n := range list {
+			values[w] = n.Vectors
+		}
+		nodes[name] = values
+	}
+	lines = append(lines, formatPerWorkerRows(nodes)...)
+	return append(lines, "")
+}
+
+func formatPerWorkerRows(rows map[string][]uint64) []string {
+	names := make([]string, 0, len(rows))
+	totals := make(map[string]uint64, len(rows))
+	for name, values := range rows {
+		names = append(names, name)
+		for _, v := range values {
+			totals[name] += v
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if totals[names[i]] != totals[names[j]] {
+			return totals[names[i]] > totals[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	var lines []string
+	for _, name := range names {
+		values := rows[name]
+		worker, share := vpp.WorkerSkew(vpp.Uint64sToFloats(values))
+		skewed := share > vpp.DefaultSkewThreshold
+		cols := make([]string, len(values))
+		for w, v := range values {
+			cols[w] = fmt.Sprint(v)
+			if skewed && w == worker {
+				cols[w] = fmt.Sprintf("[red]%d[-]", v)
+			}
+		}
+		line := fmt.Sprintf("%12d  %s  [gray](%s)[-]", totals[name], name, strings.Join(cols, " "))
+		if skewed {
+			line += fmt.Sprintf(" [red]skew W%d %.0f%%[-]", worker, share*100)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
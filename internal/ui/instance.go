@@ -105,6 +105,11 @@ func (a *App) update(index int) {
 
 		{
 			list, err := instance.ListInterfaces()
+			if err == nil {
+				if err := instance.AddInterfaceDetails(list); err != nil {
+					log.Debugf("AddInterfaceDetails failed: %v", err)
+				}
+			}
 			reload(func() {
 				if err != nil {
 					instance.Error = err
@@ -129,6 +134,13 @@ func (a *App) update(index int) {
 
 		{
 			stats, err := instance.ListStats()
+			if err == nil {
+				if vppStats, err := instance.DumpStats(); err != nil {
+					log.Errorf("DumpStats failed: %v", err)
+				} else {
+					stats = append(formatPerWorkerStats(vppStats), stats...)
+				}
+			}
 			reload(func() {
 				if err != nil {
 					instance.Error = err
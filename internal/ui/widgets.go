@@ -225,6 +225,7 @@ func (t *InterfaceTable) SetInterfaces(interfaces []*api.Interface) {
 	}
 	header := []string{
 		"Interface", "Alias", "Idx", "Type", "Status", "IP", "VRF", "MTUs",
+		"Link", "Queues", "Details", "Features",
 	}
 	for i := range header {
 		tableCell := tview.NewTableCell(header[i])
@@ -245,6 +246,10 @@ func (t *InterfaceTable) SetInterfaces(interfaces []*api.Interface) {
 			formatInterfaceIPs(iface.IPs),
 			formatInterfaceVRF(iface.VRF),
 			formatInterfaceMTU(iface.MTUs),
+			formatInterfaceLink(iface.Link),
+			formatInterfaceQueues(iface),
+			formatInterfaceDetails(iface),
+			formatInterfaceFeatures(iface.Features),
 		}
 		row := idx + 1
 		for column, col := range cols {
@@ -0,0 +1,163 @@
+// Package metrics handles exposition of metrics in Prometheus text format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Type is a metric type.
+type Type string
+
+const (
+	Counter Type = "counter"
+	Gauge   Type = "gauge"
+)
+
+// Labels is a set of metric labels.
+type Labels map[string]string
+
+// Family is a group of samples with same metric name.
+type Family struct {
+	Name    string
+	Help    string
+	Type    Type
+	Samples []Sample
+}
+
+// Sample is a single metric value with labels.
+type Sample struct {
+	Labels Labels
+	Value  float64
+}
+
+// Set is a collection of metric families.
+type Set struct {
+	families map[string]*Family
+}
+
+// NewSet returns a new empty set.
+func NewSet() *Set {
+	return &Set{
+		families: map[string]*Family{},
+	}
+}
+
+// Add adds sample to family with name, creating the family if needed.
+func (s *Set) Add(name, help string, typ Type, labels Labels, value float64) {
+	f, ok := s.families[name]
+	if !ok {
+		f = &Family{
+			Name: name,
+			Help: help,
+			Type: typ,
+		}
+		s.families[name] = f
+	}
+	f.Samples = append(f.Samples, Sample{
+		Labels: labels,
+		Value:  value,
+	})
+}
+
+// Families returns list of families sorted by name.
+func (s *Set) Families() []*Family {
+	list := make([]*Family, 0, len(s.families))
+	for _, f := range s.families {
+		list = append(list, f)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// WriteText writes all families in the Prometheus text exposition format.
+func (s *Set) WriteText(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, f := range s.Families() {
+		if f.Help != "" {
+			fmt.Fprintf(bw, "# HELP %s %s\n", f.Name, escapeHelp(f.Help))
+		}
+		fmt.Fprintf(bw, "# TYPE %s %s\n", f.Name, f.Type)
+		lines := make([]string, 0, len(f.Samples))
+		for _, sample := range f.Samples {
+			lines = append(lines, fmt.Sprintf("%s%s %s\n", f.Name, formatLabels(sample.Labels), formatValue(sample.Value)))
+		}
+		sort.Strings(lines)
+		for _, line := range lines {
+			bw.WriteString(line)
+		}
+	}
+	return bw.Flush()
+}
+
+func formatLabels(labels Labels) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(SanitizeName(k))
+		b.WriteString(`="`)
+		b.WriteString(escapeLabelValue(labels[k]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeLabelValue(s string) string {
+	return labelValueReplacer.Replace(s)
+}
+
+var helpReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeHelp(s string) string {
+	return helpReplacer.Replace(s)
+}
+
+// SanitizeName replaces characters not allowed in metric and label names with underscore.
+func SanitizeName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+			b.WriteRune(r)
+		case r >= '0' && r <= '9' && i > 0:
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
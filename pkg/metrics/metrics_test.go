@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+
+	govppapi "go.fd.io/govpp/api"
+
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+func TestWriteText(t *testing.T) {
+	set := NewSet()
+	labels := Labels{InstanceLabel: "kube::default/vpp-1", "pod": "vpp-1", "namespace": "default"}
+	AddUp(set, labels, true)
+	AddVppStats(set, &api.VppStats{
+		System: govppapi.SystemStats{VectorRate: 2},
+		Interfaces: map[string]api.InterfaceStats{
+			"tap0": {Rx: &api.InterfaceCounter{Packets: 10, Bytes: 1000}, Drops: 1},
+		},
+		Counters: map[string]uint64{
+			`ip4-input/ip4 "ttl" expired`: 3,
+		},
+		Nodes: []govppapi.NodeCounters{
+			{NodeName: "ip4-input", Calls: 5, Vectors: 7},
+			{NodeName: "unused"},
+		},
+	}, labels)
+
+	var buf bytes.Buffer
+	if err := set.WriteText(&buf); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"# TYPE vpp_up gauge\nvpp_up{namespace=\"default\",pod=\"vpp-1\",vpp_instance=\"kube::default/vpp-1\"} 1\n",
+		"vpp_interface_rx_packets_total{interface=\"tap0\",namespace=\"default\",pod=\"vpp-1\",vpp_instance=\"kube::default/vpp-1\"} 10\n",
+		"vpp_interface_drops_total{interface=\"tap0\",namespace=\"default\",pod=\"vpp-1\",vpp_instance=\"kube::default/vpp-1\"} 1\n",
+		"vpp_error_counter_total{counter=\"ip4-input/ip4 \\\"ttl\\\" expired\",namespace=\"default\",pod=\"vpp-1\",vpp_instance=\"kube::default/vpp-1\"} 3\n",
+		"vpp_node_vectors_total{namespace=\"default\",pod=\"vpp-1\",vpp_instance=\"kube::default/vpp-1\",vpp_node=\"ip4-input\"} 7\n",
+		"vpp_system_vector_rate{namespace=\"default\",pod=\"vpp-1\",vpp_instance=\"kube::default/vpp-1\"} 2\n",
+	} {
+		if !bytes.Contains(buf.Bytes(), []byte(want)) {
+			t.Errorf("output does not contain %q:\n%s", want, buf.String())
+		}
+	}
+	if bytes.Contains(buf.Bytes(), []byte(`vpp_node="unused"`)) {
+		t.Errorf("output contains unused node:\n%s", buf.String())
+	}
+}
+
+func TestNodeLabels(t *testing.T) {
+	set := NewSet()
+	AddVppStats(set, &api.VppStats{
+		Nodes: []govppapi.NodeCounters{
+			{NodeName: "ip4-input", Calls: 5, Vectors: 7},
+		},
+	}, Labels{"pod": "vpp-1", "node": "worker-1"})
+
+	var buf bytes.Buffer
+	if err := set.WriteText(&buf); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+
+	want := "vpp_node_vectors_total{node=\"worker-1\",pod=\"vpp-1\",vpp_node=\"ip4-input\"} 7\n"
+	if !bytes.Contains(buf.Bytes(), []byte(want)) {
+		t.Errorf("output does not contain %q:\n%s", want, buf.String())
+	}
+}
+
+func TestSanitizeName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"pod", "pod"},
+		{"host-ip", "host_ip"},
+		{"1st", "_st"},
+		{"image_id2", "image_id2"},
+	}
+	for _, tt := range tests {
+		if got := SanitizeName(tt.name); got != tt.want {
+			t.Errorf("SanitizeName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"strconv"
+
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+const namespace = "vpp_"
+
+// InstanceLabel is a label identifying VPP instance. It differs from
+// "instance" which is a target label attached by Prometheus.
+const InstanceLabel = namespace + "instance"
+
+// AddVppStats adds metrics for VPP stats to set with labels added to every sample.
+func AddVppStats(set *Set, stats *api.VppStats, labels Labels) {
+	if stats == nil {
+		return
+	}
+
+	sys := stats.System
+	set.Add(namespace+"system_vector_rate", "Vector rate of VPP", Gauge, labels, float64(sys.VectorRate))
+	set.Add(namespace+"system_input_rate", "Input rate of VPP", Gauge, labels, float64(sys.InputRate))
+	set.Add(namespace+"system_num_worker_threads", "Number of VPP worker threads", Gauge, labels, float64(sys.NumWorkerThreads))
+	set.Add(namespace+"system_heartbeat", "Heartbeat of VPP", Gauge, labels, float64(sys.Heartbeat))
+	for worker, rate := range sys.VectorRatePerWorker {
+		set.Add(namespace+"system_worker_vector_rate", "Vector rate of VPP worker", Gauge,
+			withLabels(labels, "worker", strconv.Itoa(worker)), float64(rate))
+	}
+
+	for name, c := range stats.Interfaces {
+		l := withLabels(labels, "interface", name)
+		if c.Rx != nil {
+			set.Add(namespace+"interface_rx_packets_total", "Received packets", Counter, l, float64(c.Rx.Packets))
+			set.Add(namespace+"interface_rx_bytes_total", "Received bytes", Counter, l, float64(c.Rx.Bytes))
+		}
+		if c.Tx != nil {
+			set.Add(namespace+"interface_tx_packets_total", "Transmitted packets", Counter, l, float64(c.Tx.Packets))
+			set.Add(namespace+"interface_tx_bytes_total", "Transmitted bytes", Counter, l, float64(c.Tx.Bytes))
+		}
+		set.Add(namespace+"interface_rx_errors_total", "Receive errors", Counter, l, float64(c.RxErrors))
+		set.Add(namespace+"interface_tx_errors_total", "Transmit errors", Counter, l, float64(c.TxErrors))
+		set.Add(namespace+"interface_drops_total", "Dropped packets", Counter, l, float64(c.Drops))
+		set.Add(namespace+"interface_punts_total", "Punted packets", Counter, l, float64(c.Punts))
+		set.Add(namespace+"interface_rx_no_buf_total", "Packets dropped due to no buffers", Counter, l, float64(c.RxNoBuf))
+		set.Add(namespace+"interface_rx_miss_total", "Missed packets", Counter, l, float64(c.RxMiss))
+	}
+
+	for name, value := range stats.Counters {
+		set.Add(namespace+"error_counter_total", "Error counter value summed across workers", Counter,
+			withLabels(labels, "counter", name), float64(value))
+	}
+
+	for _, n := range stats.Nodes {
+		if n.Calls == 0 {
+			continue
+		}
+		// "node" label is used for Kubernetes node
+		l := withLabels(labels, "vpp_node", n.NodeName)
+		set.Add(namespace+"node_clocks_total", "Node clocks", Counter, l, float64(n.Clocks))
+		set.Add(namespace+"node_vectors_total", "Node vectors", Counter, l, float64(n.Vectors))
+		set.Add(namespace+"node_calls_total", "Node calls", Counter, l, float64(n.Calls))
+		set.Add(namespace+"node_suspends_total", "Node suspends", Counter, l, float64(n.Suspends))
+	}
+}
+
+// AddUp adds metric reporting whether stats of instance could be collected.
+func AddUp(set *Set, labels Labels, up bool) {
+	var v float64
+	if up {
+		v = 1
+	}
+	set.Add(namespace+"up", "Whether VPP stats could be collected", Gauge, labels, v)
+}
+
+func withLabels(labels Labels, kv ...string) Labels {
+	l := make(Labels, len(labels)+len(kv)/2)
+	for k, v := range labels {
+		l[k] = v
+	}
+	for i := 0; i+1 < len(kv); i += 2 {
+		l[kv[i]] = kv[i+1]
+	}
+	return l
+}
@@ -0,0 +1,27 @@
+package probe
+
+import (
+	"time"
+)
+
+// ContainerInspector is an optional interface implemented by handlers
+// for instances running in containers.
+type ContainerInspector interface {
+	// ContainerState returns current state of the container.
+	ContainerState() (*ContainerState, error)
+
+	// PreviousLogs returns last lines of logs from the previous run of the container.
+	PreviousLogs(tail int) ([]string, error)
+}
+
+// ContainerState describes state of the container where the instance is running.
+type ContainerState struct {
+	Status       string
+	RestartCount int
+	StartedAt    time.Time `json:",omitempty"`
+
+	// last termination of the container
+	LastExitCode   int       `json:",omitempty"`
+	LastReason     string    `json:",omitempty"`
+	LastFinishedAt time.Time `json:",omitempty"`
+}
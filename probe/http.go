@@ -0,0 +1,9 @@
+package probe
+
+// HTTPAccessor is an optional interface implemented by handlers that can
+// reach HTTP servers running alongside the instance (e.g. agent REST API).
+type HTTPAccessor interface {
+	// HTTPAddr returns address (host:port) that can be used locally
+	// to access HTTP server listening on port of the instance.
+	HTTPAddr(port int) (string, error)
+}
@@ -1,6 +1,7 @@
 package docker
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 	"time"
@@ -103,6 +104,14 @@ func (h *ContainerHandler) GetStats() (govppapi.StatsProvider, error) {
 	return proxyStats(h.vppProxy)
 }
 
+func (h *ContainerHandler) HTTPAddr(port int) (string, error) {
+	ipaddr := getContainerIp(h.container)
+	if ipaddr == "" {
+		return "", fmt.Errorf("container %v has no IP address", h.container.Name)
+	}
+	return fmt.Sprintf("%s:%d", ipaddr, port), nil
+}
+
 func (h *ContainerHandler) connectProxy() error {
 	if h.vppProxy != nil {
 		return nil
@@ -150,6 +159,67 @@ func (*binapiClient) Close() {
 	// ignore
 }
 
+func (h *ContainerHandler) ContainerState() (*probe.ContainerState, error) {
+	container, err := h.client.InspectContainerWithOptions(docker.InspectContainerOptions{
+		ID: h.container.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	state := &probe.ContainerState{
+		Status:       container.State.StateString(),
+		RestartCount: container.RestartCount,
+		StartedAt:    container.State.StartedAt,
+	}
+	if !container.State.FinishedAt.IsZero() {
+		state.LastExitCode = container.State.ExitCode
+		state.LastReason = container.State.Error
+		if container.State.OOMKilled {
+			state.LastReason = "OOMKilled"
+		}
+		state.LastFinishedAt = container.State.FinishedAt
+	}
+	return state, nil
+}
+
+// PreviousLogs returns last lines of container logs written before
+// the container was last started.
+func (h *ContainerHandler) PreviousLogs(tail int) ([]string, error) {
+	state, err := h.ContainerState()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	err = h.client.Logs(docker.LogsOptions{
+		Container:    h.container.ID,
+		OutputStream: &buf,
+		ErrorStream:  &buf,
+		Stdout:       true,
+		Stderr:       true,
+		Timestamps:   true,
+		Tail:         "all",
+	})
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		ts, msg, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil || !t.Before(state.StartedAt) {
+			continue
+		}
+		lines = append(lines, msg)
+	}
+	if len(lines) > tail {
+		lines = lines[len(lines)-tail:]
+	}
+	return lines, nil
+}
+
 func getContainerName(container *docker.Container) string {
 	return strings.TrimPrefix(container.Name, "/")
 }
@@ -2,12 +2,14 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/remotecommand"
@@ -92,6 +94,29 @@ func getPodFirstContainerStatus(pod *corev1.Pod) corev1.ContainerStatus {
 	return pod.Status.ContainerStatuses[0]
 }
 
+// FirstContainerStatus retrieves current status of the first container of the pod.
+func (pod Pod) FirstContainerStatus() (corev1.ContainerStatus, error) {
+	p, err := pod.client.client.CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+	if err != nil {
+		return corev1.ContainerStatus{}, err
+	}
+	return getPodFirstContainerStatus(p), nil
+}
+
+// PreviousLogs returns last lines of logs from the previous instance of the first container.
+func (pod Pod) PreviousLogs(tailLines int64) (string, error) {
+	opts := &corev1.PodLogOptions{
+		Container: getPodFirstContainer(pod.pod).Name,
+		Previous:  true,
+		TailLines: &tailLines,
+	}
+	data, err := pod.client.client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).DoRaw(context.TODO())
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
 // ExecContainer executes a command in a container of the pod.
 func (pod Pod) ExecContainer(container, command string, stdin io.Reader, stdout, stderr io.Writer) error {
 	return podExec(pod.client, pod.Namespace, pod.Name, container, command, stdin, stdout, stderr)
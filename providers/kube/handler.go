@@ -2,6 +2,7 @@ package kube
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -23,6 +24,8 @@ type PodHandler struct {
 
 	vppProxy  *proxy.Client
 	portFwder *client.PortForwarder
+	// port forwarders for other ports than defaultHttpPort
+	portFwders map[int]*client.PortForwarder
 }
 
 // NewHandler returns a new handler for an instance running in a pod.
@@ -99,15 +102,39 @@ func (h *PodHandler) Close() error {
 	if h.portFwder != nil {
 		h.portFwder.Stop()
 	}
+	for port, portFwder := range h.portFwders {
+		portFwder.Stop()
+		delete(h.portFwders, port)
+	}
 	return nil
 }
 
-func (h *PodHandler) connectProxy() error {
-	if h.vppProxy != nil {
-		return nil // proxy already running
+func (h *PodHandler) HTTPAddr(port int) (string, error) {
+	if port != defaultHttpPort {
+		portFwder, ok := h.portFwders[port]
+		if !ok {
+			var err error
+			portFwder, err = h.pod.PortForward(port)
+			if err != nil {
+				return "", fmt.Errorf("port forwarding failed: %v", err)
+			}
+			if h.portFwders == nil {
+				h.portFwders = map[int]*client.PortForwarder{}
+			}
+			h.portFwders[port] = portFwder
+		}
+		return fmt.Sprintf("127.0.0.1:%d", portFwder.LocalPort()), nil
+	}
+	if err := h.startPortForward(); err != nil {
+		return "", err
 	}
+	return fmt.Sprintf("127.0.0.1:%d", h.portFwder.LocalPort()), nil
+}
 
-	logrus.Debugf("connecting to VPP proxy on pod %v", h.pod)
+func (h *PodHandler) startPortForward() error {
+	if h.portFwder != nil {
+		return nil // port forwarding already running
+	}
 
 	// start port forwarding to HTTP server on agent
 	portFwder, err := h.pod.PortForward(defaultHttpPort)
@@ -116,28 +143,41 @@ func (h *PodHandler) connectProxy() error {
 	}
 	h.portFwder = portFwder
 
-	addr := fmt.Sprintf(":%d", portFwder.LocalPort())
+	go func() {
+		select {
+		case err := <-portFwder.Done():
+			logrus.Tracef("port forwarder done (err: %v)", err)
+			portFwder.Stop()
+			h.portFwder = nil
+			h.vppProxy = nil
+		}
+	}()
+
+	return nil
+}
+
+func (h *PodHandler) connectProxy() error {
+	if h.vppProxy != nil {
+		return nil // proxy already running
+	}
+
+	logrus.Debugf("connecting to VPP proxy on pod %v", h.pod)
+
+	if err := h.startPortForward(); err != nil {
+		return err
+	}
+
+	addr := fmt.Sprintf(":%d", h.portFwder.LocalPort())
 	logrus.Debugf("connecting to proxy %v", addr)
 
 	// connect to VPP proxy via HTTP server (go RPC)
 	c, err := proxy.Connect(addr)
 	if err != nil {
-		h.portFwder.Stop()
-		h.portFwder = nil
 		return fmt.Errorf("connecting to proxy failed: %v", err)
 	}
 
 	h.vppProxy = c
 
-	go func() {
-		select {
-		case err := <-h.portFwder.Done():
-			logrus.Tracef("port forwarder done (err: %v)", err)
-			h.portFwder.Stop()
-			h.vppProxy = nil
-		}
-	}()
-
 	return nil
 }
 
@@ -168,3 +208,36 @@ type binapiClient struct {
 func (*binapiClient) Close() {
 	// ignore
 }
+
+func (h *PodHandler) ContainerState() (*probe.ContainerState, error) {
+	status, err := h.pod.FirstContainerStatus()
+	if err != nil {
+		return nil, err
+	}
+	state := &probe.ContainerState{
+		RestartCount: int(status.RestartCount),
+	}
+	switch s := status.State; {
+	case s.Running != nil:
+		state.Status = "running"
+		state.StartedAt = s.Running.StartedAt.Time
+	case s.Waiting != nil:
+		state.Status = "waiting: " + s.Waiting.Reason
+	case s.Terminated != nil:
+		state.Status = "terminated: " + s.Terminated.Reason
+	}
+	if last := status.LastTerminationState.Terminated; last != nil {
+		state.LastExitCode = int(last.ExitCode)
+		state.LastReason = last.Reason
+		state.LastFinishedAt = last.FinishedAt.Time
+	}
+	return state, nil
+}
+
+func (h *PodHandler) PreviousLogs(tail int) ([]string, error) {
+	logs, err := h.pod.PreviousLogs(int64(tail))
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.TrimRight(logs, "\n"), "\n"), nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"go.fd.io/govpp"
+	"go.fd.io/govpp/adapter"
 	"go.fd.io/govpp/adapter/statsclient"
 	govppapi "go.fd.io/govpp/api"
 	govppcore "go.fd.io/govpp/core"
@@ -38,6 +39,7 @@ type ProcessHandler struct {
 
 	binapiConn *govppcore.Connection
 	statsConn  *govppcore.StatsConnection
+	statsDir   adapter.StatsAPI
 }
 
 // NewHandler returns a new handler for a local instance specified by PID.
@@ -63,6 +65,10 @@ func (h *ProcessHandler) Command(cmd string, args ...string) exec.Cmd {
 	return exec.Command(cmd, args...)
 }
 
+func (h *ProcessHandler) HTTPAddr(port int) (string, error) {
+	return fmt.Sprintf("127.0.0.1:%d", port), nil
+}
+
 func (h *ProcessHandler) GetCLI() (probe.CliExecutor, error) {
 	wrapper := exec.Wrap(h, "/usr/bin/vppctl", "-s", h.CliAddr)
 	cli := vppcli.ExecutorFunc(func(cmd string) (string, error) {
@@ -99,9 +105,13 @@ func (h *ProcessHandler) GetStats() (govppapi.StatsProvider, error) {
 			return nil, fmt.Errorf("connecting to stats failed: %w", err)
 		}
 		h.statsConn = conn
+		h.statsDir = statsAdapter
 	}
 
-	return h.statsConn, nil
+	return &statsProvider{
+		StatsConnection: h.statsConn,
+		dir:             h.statsDir,
+	}, nil
 }
 
 func (h *ProcessHandler) Close() error {
@@ -112,6 +122,18 @@ func (h *ProcessHandler) Close() error {
 	if h.statsConn != nil {
 		h.statsConn.Disconnect()
 		h.statsConn = nil
+		h.statsDir = nil
 	}
 	return nil
 }
+
+// statsProvider extends stats connection with access to raw stats directory.
+type statsProvider struct {
+	*govppcore.StatsConnection
+	dir adapter.StatsAPI
+}
+
+// DumpStats dumps stats segment entries matching patterns.
+func (s *statsProvider) DumpStats(patterns ...string) ([]adapter.StatEntry, error) {
+	return s.dir.DumpStats(patterns...)
+}
@@ -2,8 +2,8 @@
 package agent
 
 import (
-	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -13,20 +13,49 @@ import (
 
 type Instance struct {
 	handler probe.Handler
+	client  *Client
 
 	Config *Config
 	Info   *Info
+	Txns   []Txn `json:",omitempty"`
 }
 
 func NewInstance(handler probe.Handler) (*Instance, error) {
 	instance := &Instance{
 		handler: handler,
+		client:  NewClient(handler),
 	}
 	return instance, instance.Init()
 }
 
+// NewInstanceFromConfig returns instance for config that was not
+// retrieved from agent.
+func NewInstanceFromConfig(handler probe.Handler, config *Config) *Instance {
+	return &Instance{
+		handler: handler,
+		Config:  config,
+	}
+}
+
+// ID returns ID of the instance handler.
+func (instance *Instance) ID() string {
+	if instance.handler == nil {
+		return ""
+	}
+	return instance.handler.ID()
+}
+
+// Client returns client used for retrieving data from agent, creating it
+// on first use.
+func (instance *Instance) Client() *Client {
+	if instance.client == nil {
+		instance.client = NewClient(instance.handler)
+	}
+	return instance.client
+}
+
 func (instance *Instance) Init() (err error) {
-	instance.Info, err = RetrieveInfo(instance.handler)
+	instance.Info, err = RetrieveInfo(instance.Client())
 	if err != nil {
 		return fmt.Errorf("retrieving status failed: %w", err)
 	}
@@ -39,7 +68,7 @@ func (instance *Instance) UpdateInstanceInfo() (err error) {
 		"instance": instance.handler.ID(),
 	}), "updating instance info")()
 
-	instance.Config, err = RetrieveConfig(instance.handler)
+	instance.Config, err = RetrieveConfig(instance.Client())
 	if err != nil {
 		return fmt.Errorf("retrieving config failed: %w", err)
 	}
@@ -47,28 +76,28 @@ func (instance *Instance) UpdateInstanceInfo() (err error) {
 	return nil
 }
 
-type Info struct {
-	Status struct {
-		BuildVersion string `json:"build_version"`
-		BuildDate    string `json:"build_date"`
-	}
-}
-
-func RetrieveInfo(handler probe.Handler) (*Info, error) {
-	log := logrus.WithFields(map[string]interface{}{
-		"instance": handler.ID(),
-	})
-
-	out, err := runAgentctlCmd(handler, "status", "-f", "json")
+// UpdateTxnHistory retrieves transactions started after since.
+func (instance *Instance) UpdateTxnHistory(since time.Time) (err error) {
+	instance.Txns, err = RetrieveTxnHistory(instance.Client(), since)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
-	var info *Info
-	if err := json.Unmarshal(out, &info); err != nil {
-		log.Tracef("status json data: %s", out)
-		return nil, fmt.Errorf("unmarshaling failed: %w", err)
+	if t := lastResync(instance.Txns); instance.Info != nil && !t.IsZero() {
+		instance.Info.LastResync = t
 	}
+	return nil
+}
+
+type Info struct {
+	Status     AgentStatus
+	Plugins    []PluginStatus `json:",omitempty"`
+	KVStore    *PluginStatus  `json:",omitempty"`
+	LastResync time.Time      `json:",omitempty"`
+	Errors     []string       `json:",omitempty"`
+	// Transport is the way used for communication with agent.
+	Transport Transport `json:",omitempty"`
+}
 
-	return info, nil
+func RetrieveInfo(c *Client) (*Info, error) {
+	return c.Info()
 }
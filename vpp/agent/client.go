@@ -0,0 +1,306 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/kvscheduler"
+
+	"go.ligato.io/vpp-probe/probe"
+)
+
+// DefaultAgentHTTPPort is a default port of agent REST API.
+const DefaultAgentHTTPPort = 9191
+
+const (
+	// livenessTimeout limits probing of agent REST API so that unreachable
+	// agents do not stall discovery before falling back to agentctl.
+	livenessTimeout = 2 * time.Second
+	requestTimeout  = 10 * time.Second
+)
+
+// Transport is a way of communication with agent.
+type Transport string
+
+const (
+	// TransportREST uses agent REST API.
+	TransportREST Transport = "rest"
+	// TransportAgentctl executes agentctl in the instance.
+	TransportAgentctl Transport = "agentctl"
+)
+
+// Client retrieves data from agent using its REST API and falls back to
+// executing agentctl on the instance when REST API is not reachable.
+type Client struct {
+	handler probe.Handler
+	baseURL string
+	http    *http.Client
+
+	Transport Transport
+}
+
+// NewClient returns a new client for agent running with handler instance.
+// It uses REST API if the handler implements probe.HTTPAccessor and
+// the agent HTTP server responds, otherwise it uses agentctl.
+func NewClient(handler probe.Handler) *Client {
+	log := logrus.WithFields(map[string]interface{}{
+		"instance": handler.ID(),
+	})
+
+	c := &Client{
+		handler:   handler,
+		http:      &http.Client{Timeout: requestTimeout},
+		Transport: TransportAgentctl,
+	}
+	accessor, ok := handler.(probe.HTTPAccessor)
+	if !ok {
+		return c
+	}
+	addr, err := accessor.HTTPAddr(DefaultAgentHTTPPort)
+	if err != nil {
+		log.Debugf("agent HTTP address unavailable, using agentctl: %v", err)
+		return c
+	}
+	c.baseURL = "http://" + addr
+	if err := c.probeLiveness(); err != nil {
+		log.Debugf("agent REST API unreachable, using agentctl: %v", err)
+		return c
+	}
+	c.Transport = TransportREST
+	log.Debugf("using agent REST API at %s", c.baseURL)
+	return c
+}
+
+// probeLiveness checks that agent HTTP server responds within livenessTimeout.
+func (c *Client) probeLiveness() error {
+	hc := &http.Client{Timeout: livenessTimeout}
+	resp, err := hc.Get(c.baseURL + "/liveness")
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// get sends GET request to agent REST API and returns response body and status code.
+func (c *Client) get(path string, query url.Values) ([]byte, int, error) {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	resp, err := c.http.Get(u)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+	logrus.Tracef("GET %s: %s (%d bytes)", u, resp.Status, len(body))
+	return body, resp.StatusCode, nil
+}
+
+// getOK sends GET request and returns error unless response status is 200.
+func (c *Client) getOK(path string, query url.Values) ([]byte, error) {
+	body, code, err := c.get(path, query)
+	if err != nil {
+		return nil, err
+	}
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %d %s: %s", path, code, http.StatusText(code), body)
+	}
+	return body, nil
+}
+
+// useREST calls fn if REST API is used and reports whether it succeeded.
+func (c *Client) useREST(what string, fn func() error) bool {
+	if c.Transport != TransportREST {
+		return false
+	}
+	if err := fn(); err != nil {
+		logrus.WithField("instance", c.handler.ID()).
+			Debugf("retrieving %s via REST API failed, falling back to agentctl: %v", what, err)
+		return false
+	}
+	return true
+}
+
+// Info returns agent status info.
+func (c *Client) Info() (*Info, error) {
+	var info *Info
+	if c.useREST("status", func() error {
+		// readiness responds with 503 when agent is not ready
+		body, code, err := c.get("/readiness", nil)
+		if err != nil {
+			return err
+		}
+		if code != http.StatusOK && code != http.StatusServiceUnavailable {
+			return fmt.Errorf("unexpected status %d", code)
+		}
+		info, err = parseInfo(body)
+		return err
+	}) {
+		info.Transport = c.Transport
+		return info, nil
+	}
+
+	out, err := runAgentctlCmd(c.handler, "status", "-f", "json")
+	if err != nil {
+		return nil, err
+	}
+	info, err = parseInfo(out)
+	if err != nil {
+		logrus.Tracef("status json data: %s", out)
+		return nil, fmt.Errorf("unmarshaling failed: %w", err)
+	}
+	info.Transport = TransportAgentctl
+	return info, nil
+}
+
+// Dump returns all values from view of kvscheduler.
+func (c *Client) Dump(view string) ([]KVData, error) {
+	var list []KVData
+	if c.useREST("dump", func() (err error) {
+		list, err = c.restDump(view)
+		return err
+	}) {
+		return list, nil
+	}
+
+	dump, err := runAgentctlCmd(c.handler, "dump", "--format", `'{{printf "["}}{{range $i, $e := .}}{{if $i}}, {{end}}{{printf "{ \"Key\": \"%s\",\n" $e.Key}}{{printf "\"Value\": %s,\n" (json $e.Value)}}{{printf "\"Metadata\": %s,\n\"Origin\": \"%v\"\n}" (json $e.Metadata) ($e.Origin)}}{{end}}{{printf "]"}}'`, "--view", view, "all")
+	if err != nil {
+		return nil, fmt.Errorf("executing dump failed: %w", err)
+	}
+	logrus.Tracef("dump response %d bytes", len(dump))
+
+	if err := json.Unmarshal(dump, &list); err != nil {
+		logrus.Tracef("dump json data: %s", dump)
+		return nil, fmt.Errorf("unmarshaling dump failed: %w", err)
+	}
+	return list, nil
+}
+
+func (c *Client) restDump(view string) ([]KVData, error) {
+	// without parameters dump returns index of descriptors
+	body, err := c.getOK("/scheduler/dump", nil)
+	if err != nil {
+		return nil, err
+	}
+	var index struct {
+		Descriptors []string
+		KeyPrefixes []string
+	}
+	if err := json.Unmarshal(body, &index); err != nil {
+		return nil, fmt.Errorf("unmarshaling dump index failed: %w", err)
+	}
+
+	param, values := "key-prefix", index.KeyPrefixes
+	if len(values) == 0 {
+		param, values = "descriptor", index.Descriptors
+	}
+	list := []KVData{}
+	for _, v := range values {
+		body, err := c.getOK("/scheduler/dump", url.Values{param: {v}, "view": {view}})
+		if err != nil {
+			return nil, err
+		}
+		kvs, err := parseRecordedKVs(body)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshaling dump of %s failed: %w", v, err)
+		}
+		list = append(list, kvs...)
+	}
+	return list, nil
+}
+
+// recordedKV is a value as dumped by kvscheduler REST API.
+type recordedKV struct {
+	Key   string
+	Value *struct {
+		ProtoMsgName string
+		ProtoMsgData json.RawMessage
+	}
+	Metadata map[string]interface{}
+	Origin   ValueOrigin
+}
+
+func parseRecordedKVs(data []byte) ([]KVData, error) {
+	var recorded []recordedKV
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return nil, err
+	}
+	list := make([]KVData, 0, len(recorded))
+	for _, r := range recorded {
+		kv := KVData{
+			Key:      r.Key,
+			Metadata: r.Metadata,
+			Origin:   r.Origin,
+			Value:    json.RawMessage("null"),
+		}
+		if r.Value != nil && len(r.Value.ProtoMsgData) > 0 {
+			kv.Value = r.Value.ProtoMsgData
+			// message data may be encoded as JSON string
+			var s string
+			if err := json.Unmarshal(r.Value.ProtoMsgData, &s); err == nil {
+				kv.Value = json.RawMessage(s)
+			}
+		}
+		list = append(list, kv)
+	}
+	return list, nil
+}
+
+// Values returns kvscheduler statuses of all values.
+func (c *Client) Values() ([]*kvscheduler.BaseValueStatus, error) {
+	var resp []byte
+	if !c.useREST("values", func() (err error) {
+		resp, err = c.getOK("/scheduler/status", nil)
+		return err
+	}) {
+		var err error
+		resp, err = runAgentctlCmd(c.handler, "values", "--format", "json")
+		if err != nil {
+			return nil, fmt.Errorf("dumping all failed: %w", err)
+		}
+	}
+	logrus.Tracef("values response %d bytes", len(resp))
+
+	var values []*kvscheduler.BaseValueStatus
+	if err := json.Unmarshal(resp, &values); err != nil {
+		logrus.Tracef("json data: %s", resp)
+		return nil, fmt.Errorf("unmarshaling status values failed: %w", err)
+	}
+	return values, nil
+}
+
+// TxnHistory returns transactions started after since (all if zero).
+func (c *Client) TxnHistory(since time.Time) ([]Txn, error) {
+	var resp []byte
+	if !c.useREST("transaction history", func() (err error) {
+		query := url.Values{"format": {"json"}}
+		if !since.IsZero() {
+			query.Set("since", strconv.FormatInt(since.Unix(), 10))
+		}
+		resp, err = c.getOK("/scheduler/txn-history", query)
+		return err
+	}) {
+		var err error
+		resp, err = runAgentctlCmd(c.handler, "config", "history", "--format", "json")
+		if err != nil {
+			return nil, fmt.Errorf("retrieving transaction history failed: %w", err)
+		}
+	}
+	logrus.Tracef("txn history response %d bytes", len(resp))
+
+	txns, err := parseTxnHistory(resp)
+	if err != nil {
+		return nil, err
+	}
+	return filterTxnsSince(txns, since), nil
+}
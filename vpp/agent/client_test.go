@@ -0,0 +1,255 @@
+package agent
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.ligato.io/vpp-probe/pkg/exec"
+	"go.ligato.io/vpp-probe/probe"
+)
+
+// fakeHandler is a handler with agent HTTP server at addr that
+// returns agentctl output by its first argument.
+type fakeHandler struct {
+	probe.Handler
+	addr     string
+	agentctl map[string]string
+
+	mu       sync.Mutex
+	commands []string
+}
+
+func (h *fakeHandler) ID() string { return "fake" }
+
+func (h *fakeHandler) HTTPAddr(int) (string, error) { return h.addr, nil }
+
+func (h *fakeHandler) Command(cmd string, args ...string) exec.Cmd {
+	h.mu.Lock()
+	h.commands = append(h.commands, cmd+" "+args[0])
+	h.mu.Unlock()
+	out, ok := h.agentctl[args[0]]
+	if !ok {
+		return &fakeCmd{err: fmt.Errorf("%s %s: not found", cmd, args[0])}
+	}
+	return &fakeCmd{out: []byte(out)}
+}
+
+type fakeCmd struct {
+	exec.Cmd
+	out []byte
+	err error
+}
+
+func (c *fakeCmd) Output() ([]byte, error) { return c.out, c.err }
+
+// fakeAgent is agent REST API recording queries of requests.
+type fakeAgent struct {
+	*httptest.Server
+	handlers map[string]http.HandlerFunc
+
+	mu      sync.Mutex
+	queries map[string][]url.Values
+}
+
+func newFakeAgent(t *testing.T, handlers map[string]http.HandlerFunc) *fakeAgent {
+	a := &fakeAgent{
+		handlers: handlers,
+		queries:  map[string][]url.Values{},
+	}
+	a.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.mu.Lock()
+		a.queries[r.URL.Path] = append(a.queries[r.URL.Path], r.URL.Query())
+		a.mu.Unlock()
+		h, ok := a.handlers[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		h(w, r)
+	}))
+	t.Cleanup(a.Close)
+	return a
+}
+
+func (a *fakeAgent) handler(agentctl map[string]string) *fakeHandler {
+	return &fakeHandler{
+		addr:     strings.TrimPrefix(a.URL, "http://"),
+		agentctl: agentctl,
+	}
+}
+
+func respond(code int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(code)
+		fmt.Fprint(w, body)
+	}
+}
+
+const (
+	testInfoNotReady = `{"build_version": "v3.4.0", "state": "ERROR", "plugin_status": {"etcd": {"state": "ERROR", "error": "context deadline exceeded"}}}`
+	testTxnHistory   = `[{"SeqNum":3,"TxnType":"NB_TRANSACTION","Start":"2020-09-13T12:30:00Z","Executed":[{"Operation":"CREATE","Key":"config/vpp/v2/interfaces/tap1","NewState":"CONFIGURED"}]}]`
+)
+
+func TestNewClient(t *testing.T) {
+	a := newFakeAgent(t, map[string]http.HandlerFunc{
+		"/liveness": respond(http.StatusOK, `{}`),
+	})
+	if c := NewClient(a.handler(nil)); c.Transport != TransportREST {
+		t.Errorf("reachable agent: Transport = %v, want %v", c.Transport, TransportREST)
+	}
+
+	unreachable := newFakeAgent(t, nil)
+	h := unreachable.handler(nil)
+	unreachable.Close()
+	if c := NewClient(h); c.Transport != TransportAgentctl {
+		t.Errorf("unreachable agent: Transport = %v, want %v", c.Transport, TransportAgentctl)
+	}
+
+	if c := NewClient(struct{ probe.Handler }{h}); c.Transport != TransportAgentctl {
+		t.Errorf("handler without HTTP access: Transport = %v, want %v", c.Transport, TransportAgentctl)
+	}
+}
+
+func TestClientInfoNotReady(t *testing.T) {
+	a := newFakeAgent(t, map[string]http.HandlerFunc{
+		"/liveness":  respond(http.StatusOK, `{}`),
+		"/readiness": respond(http.StatusServiceUnavailable, testInfoNotReady),
+	})
+	h := a.handler(nil)
+
+	info, err := NewClient(h).Info()
+	if err != nil {
+		t.Fatalf("Info() error = %v", err)
+	}
+	if info.Transport != TransportREST {
+		t.Errorf("Transport = %v, want %v", info.Transport, TransportREST)
+	}
+	if info.IsReady() {
+		t.Errorf("IsReady() = true for agent responding with 503")
+	}
+	if len(h.commands) > 0 {
+		t.Errorf("unexpected agentctl commands: %q", h.commands)
+	}
+}
+
+func TestClientDump(t *testing.T) {
+	a := newFakeAgent(t, map[string]http.HandlerFunc{
+		"/liveness": respond(http.StatusOK, `{}`),
+		"/scheduler/dump": func(w http.ResponseWriter, r *http.Request) {
+			switch prefix := r.URL.Query().Get("key-prefix"); prefix {
+			case "":
+				fmt.Fprint(w, `{"Descriptors": ["vpp-interface", "linux-interface"], "KeyPrefixes": ["config/vpp/v2/interfaces/", "config/linux/interfaces/v2/interface/"]}`)
+			case "config/vpp/v2/interfaces/":
+				fmt.Fprint(w, `[{"Key": "config/vpp/v2/interfaces/tap1", "Value": {"ProtoMsgData": {"name": "tap1"}}, "Origin": 1}]`)
+			default:
+				fmt.Fprint(w, `[]`)
+			}
+		},
+	})
+	h := a.handler(nil)
+
+	list, err := NewClient(h).Dump("SB")
+	if err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+	if len(list) != 1 || list[0].Key != "config/vpp/v2/interfaces/tap1" || string(list[0].Value) != `{"name": "tap1"}` {
+		t.Errorf("Dump() = %+v", list)
+	}
+	want := []url.Values{
+		{},
+		{"key-prefix": {"config/vpp/v2/interfaces/"}, "view": {"SB"}},
+		{"key-prefix": {"config/linux/interfaces/v2/interface/"}, "view": {"SB"}},
+	}
+	if got := a.queries["/scheduler/dump"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("dump queries\n got: %v\nwant: %v", got, want)
+	}
+	if len(h.commands) > 0 {
+		t.Errorf("unexpected agentctl commands: %q", h.commands)
+	}
+}
+
+func TestClientTxnHistory(t *testing.T) {
+	a := newFakeAgent(t, map[string]http.HandlerFunc{
+		"/liveness":              respond(http.StatusOK, `{}`),
+		"/scheduler/txn-history": respond(http.StatusOK, testTxnHistory),
+	})
+	h := a.handler(nil)
+	since := time.Unix(1600000000, 0)
+
+	txns, err := NewClient(h).TxnHistory(since)
+	if err != nil {
+		t.Fatalf("TxnHistory() error = %v", err)
+	}
+	if len(txns) != 1 || txns[0].SeqNum != 3 {
+		t.Errorf("TxnHistory() = %+v", txns)
+	}
+	want := []url.Values{{"format": {"json"}, "since": {"1600000000"}}}
+	if got := a.queries["/scheduler/txn-history"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("txn history queries\n got: %v\nwant: %v", got, want)
+	}
+	if len(h.commands) > 0 {
+		t.Errorf("unexpected agentctl commands: %q", h.commands)
+	}
+}
+
+func TestClientFallback(t *testing.T) {
+	a := newFakeAgent(t, map[string]http.HandlerFunc{
+		"/liveness":              respond(http.StatusOK, `{}`),
+		"/readiness":             respond(http.StatusInternalServerError, `internal error`),
+		"/scheduler/txn-history": respond(http.StatusNotFound, `not found`),
+	})
+	h := a.handler(map[string]string{
+		"status": `{"Status": {"build_version": "v3.4.0", "state": 1}}`,
+		"config": testTxnHistory,
+	})
+	c := NewClient(h)
+	if c.Transport != TransportREST {
+		t.Fatalf("Transport = %v, want %v", c.Transport, TransportREST)
+	}
+
+	info, err := c.Info()
+	if err != nil {
+		t.Fatalf("Info() error = %v", err)
+	}
+	if info.Transport != TransportAgentctl || !info.IsReady() {
+		t.Errorf("Info() = %+v, want ready info via agentctl", info)
+	}
+	txns, err := c.TxnHistory(time.Time{})
+	if err != nil {
+		t.Fatalf("TxnHistory() error = %v", err)
+	}
+	if len(txns) != 1 {
+		t.Errorf("TxnHistory() = %+v", txns)
+	}
+	if want := []string{"agentctl status", "agentctl config"}; !reflect.DeepEqual(h.commands, want) {
+		t.Errorf("agentctl commands = %q, want %q", h.commands, want)
+	}
+}
+
+func TestParseRecordedKVs(t *testing.T) {
+	data := `[
+		{"Key": "a", "Value": {"ProtoMsgName": "x", "ProtoMsgData": "{\"mtu\":1500}"}, "Origin": 1},
+		{"Key": "b", "Value": {"ProtoMsgName": "y", "ProtoMsgData": {"name": "b"}}},
+		{"Key": "c"}
+	]`
+	kvs, err := parseRecordedKVs([]byte(data))
+	if err != nil {
+		t.Fatalf("parseRecordedKVs() error = %v", err)
+	}
+	want := []string{`{"mtu":1500}`, `{"name": "b"}`, `null`}
+	if len(kvs) != len(want) {
+		t.Fatalf("expected %d values, got %d", len(want), len(kvs))
+	}
+	for i, kv := range kvs {
+		if string(kv.Value) != want[i] {
+			t.Errorf("value of %s = %s, want %s", kv.Key, kv.Value, want[i])
+		}
+	}
+}
@@ -8,34 +8,63 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
 	"go.ligato.io/vpp-agent/v3/pkg/models"
 	"go.ligato.io/vpp-agent/v3/plugins/kvscheduler/api"
 	"go.ligato.io/vpp-agent/v3/proto/ligato/kvscheduler"
 	linux_interfaces "go.ligato.io/vpp-agent/v3/proto/ligato/linux/interfaces"
 	linux_l3 "go.ligato.io/vpp-agent/v3/proto/ligato/linux/l3"
+	linux_namespace "go.ligato.io/vpp-agent/v3/proto/ligato/linux/namespace"
+	vpp_abf "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/abf"
+	vpp_acl "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/acl"
 	vpp_interfaces "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/interfaces"
 	vpp_ipsec "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/ipsec"
 	vpp_l2 "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/l2"
 	vpp_l3 "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/l3"
+	vpp_nat "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/nat"
+	vpp_punt "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/punt"
+	vpp_stn "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/stn"
+	vpp_wg "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/wireguard"
 
 	"go.ligato.io/vpp-probe/probe"
 )
 
 type Config struct {
 	VPP struct {
-		Interfaces       []VppInterface
-		Routes           []VppRoute           `json:",omitempty"`
-		L2XConnects      []VppL2XConnect      `json:",omitempty"`
-		IPSecTunProtects []VppIPSecTunProtect `json:",omitempty"`
-		IPSecSAs         []VppIPSecSA         `json:",omitempty"`
-		IPSecSPDs        []VppIPSecSPD        `json:",omitempty"`
-		IPSecSPs         []VppIPSecSP         `json:",omitempty"`
+		Interfaces        []VppInterface
+		Routes            []VppRoute            `json:",omitempty"`
+		L2XConnects       []VppL2XConnect       `json:",omitempty"`
+		IPSecTunProtects  []VppIPSecTunProtect  `json:",omitempty"`
+		IPSecSAs          []VppIPSecSA          `json:",omitempty"`
+		IPSecSPDs         []VppIPSecSPD         `json:",omitempty"`
+		IPSecSPs          []VppIPSecSP          `json:",omitempty"`
+		BridgeDomains     []VppBridgeDomain     `json:",omitempty"`
+		L2FIBs            []VppL2FIB            `json:",omitempty"`
+		ARPs              []VppARPEntry         `json:",omitempty"`
+		IPScanNeighbor    *VppIPScanNeighbor    `json:",omitempty"`
+		VrfTables         []VppVrfTable         `json:",omitempty"`
+		ACLs              []VppACL              `json:",omitempty"`
+		NAT44Global       *VppNAT44Global       `json:",omitempty"`
+		DNAT44s           []VppDNAT44           `json:",omitempty"`
+		NAT44Interfaces   []VppNAT44Interface   `json:",omitempty"`
+		NAT44AddressPools []VppNAT44AddressPool `json:",omitempty"`
+		PuntToHosts       []VppPuntToHost       `json:",omitempty"`
+		PuntIPRedirects   []VppPuntIPRedirect   `json:",omitempty"`
+		PuntExceptions    []VppPuntException    `json:",omitempty"`
+		Spans             []VppSpan             `json:",omitempty"`
+		DHCPProxies       []VppDHCPProxy        `json:",omitempty"`
+		STNRules          []VppSTNRule          `json:",omitempty"`
+		ABFs              []VppABF              `json:",omitempty"`
+		WgPeers           []VppWgPeer           `json:",omitempty"`
 	}
 	Linux struct {
 		Interfaces []LinuxInterface
-		Routes     []LinuxRoute `json:",omitempty"`
+		Routes     []LinuxRoute    `json:",omitempty"`
+		ARPs       []LinuxARPEntry `json:",omitempty"`
 	}
+	// Unknown contains raw data of values with unhandled models.
+	Unknown []KVData `json:",omitempty"`
 }
 
 // GetVppInterface returns VPP interface with name or nil if not found.
@@ -70,6 +99,15 @@ func (v *LinuxInterface) Index() int {
 type LinuxRoute struct {
 	KVData
 	Value *linux_l3.Route
+	// Namespace is a namespace of outgoing interface.
+	Namespace *linux_namespace.NetNamespace `json:",omitempty"`
+}
+
+type LinuxARPEntry struct {
+	KVData
+	Value *linux_l3.ARPEntry
+	// Namespace is a namespace of interface.
+	Namespace *linux_namespace.NetNamespace `json:",omitempty"`
 }
 
 type VppInterface struct {
@@ -115,6 +153,96 @@ type VppIPSecSP struct {
 	Value *vpp_ipsec.SecurityPolicy
 }
 
+type VppBridgeDomain struct {
+	KVData
+	Value *vpp_l2.BridgeDomain
+}
+
+type VppL2FIB struct {
+	KVData
+	Value *vpp_l2.FIBEntry
+}
+
+type VppARPEntry struct {
+	KVData
+	Value *vpp_l3.ARPEntry
+}
+
+type VppIPScanNeighbor struct {
+	KVData
+	Value *vpp_l3.IPScanNeighbor
+}
+
+type VppVrfTable struct {
+	KVData
+	Value *vpp_l3.VrfTable
+}
+
+type VppACL struct {
+	KVData
+	Value *vpp_acl.ACL
+}
+
+type VppNAT44Global struct {
+	KVData
+	Value *vpp_nat.Nat44Global
+}
+
+type VppDNAT44 struct {
+	KVData
+	Value *vpp_nat.DNat44
+}
+
+type VppNAT44Interface struct {
+	KVData
+	Value *vpp_nat.Nat44Interface
+}
+
+type VppNAT44AddressPool struct {
+	KVData
+	Value *vpp_nat.Nat44AddressPool
+}
+
+type VppPuntToHost struct {
+	KVData
+	Value *vpp_punt.ToHost
+}
+
+type VppPuntIPRedirect struct {
+	KVData
+	Value *vpp_punt.IPRedirect
+}
+
+type VppPuntException struct {
+	KVData
+	Value *vpp_punt.Exception
+}
+
+type VppSpan struct {
+	KVData
+	Value *vpp_interfaces.Span
+}
+
+type VppDHCPProxy struct {
+	KVData
+	Value *vpp_l3.DHCPProxy
+}
+
+type VppSTNRule struct {
+	KVData
+	Value *vpp_stn.Rule
+}
+
+type VppABF struct {
+	KVData
+	Value *vpp_abf.ABF
+}
+
+type VppWgPeer struct {
+	KVData
+	Value *vpp_wg.Peer
+}
+
 type KVData struct {
 	Key      string
 	Value    json.RawMessage
@@ -150,11 +278,17 @@ func (v *ValueOrigin) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func RetrieveConfig(handler probe.Handler) (*Config, error) {
-	return retrieveConfig(handler, false)
+func RetrieveConfig(c *Client) (*Config, error) {
+	return retrieveConfig(c, false)
 }
 
-func retrieveConfig(handler probe.Handler, cached bool) (*Config, error) {
+// RetrieveCachedConfig returns config from cached view of kvscheduler
+// containing values intended by NB as last applied.
+func RetrieveCachedConfig(c *Client) (*Config, error) {
+	return retrieveConfig(c, true)
+}
+
+func retrieveConfig(c *Client, cached bool) (*Config, error) {
 	var config Config
 
 	// dump running config
@@ -162,7 +296,7 @@ func retrieveConfig(handler probe.Handler, cached bool) (*Config, error) {
 	if cached {
 		viewType = "cached"
 	}
-	if err := dumpConfig(handler, &config, viewType); err != nil {
+	if err := dumpConfig(c, &config, viewType); err != nil {
 		return nil, err
 	}
 
@@ -172,33 +306,26 @@ func retrieveConfig(handler probe.Handler, cached bool) (*Config, error) {
 	})
 
 	// get status for values
-	if err := getValues(handler, &config); err != nil {
+	if err := getValues(c, &config); err != nil {
 		logrus.Errorf("getting value  failed: %v", err)
 	}
 
 	// retrieve additional metadata
-	if err := retrieveMetadata(handler, &config); err != nil {
+	if err := retrieveMetadata(c.handler, &config); err != nil {
 		logrus.Errorf("retrieving metadata failed: %v", err)
 	}
 
 	return &config, nil
 }
 
-func getValues(handler probe.Handler, c *Config) error {
+func getValues(client *Client, c *Config) error {
 	log := logrus.WithFields(map[string]interface{}{
-		"instance": handler.ID(),
+		"instance": client.handler.ID(),
 	})
 
-	resp, err := runAgentctlCmd(handler, "values", "--format", "json")
+	values, err := dumpValueStatuses(client)
 	if err != nil {
-		return fmt.Errorf("dumping all failed: %w", err)
-	}
-	log.Tracef("values response %d bytes", len(resp))
-
-	var values []*kvscheduler.BaseValueStatus
-	if err := json.Unmarshal(resp, &values); err != nil {
-		logrus.Tracef("json data: %s", resp)
-		return fmt.Errorf("unmarshaling status values failed: %w", err)
+		return err
 	}
 	log.Debugf("retrieved %d values", len(values))
 
@@ -224,28 +351,33 @@ func getValues(handler probe.Handler, c *Config) error {
 	return nil
 }
 
-func dumpConfig(handler probe.Handler, config *Config, viewType string) error {
-	log := logrus.WithFields(map[string]interface{}{
-		"instance": handler.ID(),
-	})
+// dumpValueStatuses returns kvscheduler statuses of all values.
+func dumpValueStatuses(c *Client) ([]*kvscheduler.BaseValueStatus, error) {
+	return c.Values()
+}
 
-	// execute agentctl dump
-	dump, err := runAgentctlCmd(handler, "dump", "--format", `'{{printf "["}}{{range $i, $e := .}}{{if $i}}, {{end}}{{printf "{ \"Key\": \"%s\",\n" $e.Key}}{{printf "\"Value\": %s,\n" (json $e.Value)}}{{printf "\"Metadata\": %s,\n\"Origin\": \"%v\"\n}" (json $e.Metadata) ($e.Origin)}}{{end}}{{printf "]"}}'`, "--view", viewType, "all")
+// dumpKVData returns all values from view of kvscheduler.
+func dumpKVData(c *Client, viewType string) ([]KVData, error) {
+	list, err := c.Dump(viewType)
 	if err != nil {
-		return fmt.Errorf("executing dump failed: %w", err)
-	}
-	log.Tracef("dump response %d bytes", len(dump))
-
-	var list []KVData
-	if err := json.Unmarshal(dump, &list); err != nil {
-		log.Tracef("dump json data: %s", dump)
-		return fmt.Errorf("unmarshaling dump failed: %w", err)
+		return nil, err
 	}
 	if list == nil {
-		return fmt.Errorf("unmarshaled dump is nil")
+		return nil, fmt.Errorf("unmarshaled dump is nil")
+	}
+	return list, nil
+}
+
+func dumpConfig(c *Client, config *Config, viewType string) error {
+	log := logrus.WithFields(map[string]interface{}{
+		"instance": c.handler.ID(),
+	})
+
+	list, err := dumpKVData(c, viewType)
+	if err != nil {
+		return err
 	}
 	if len(list) == 0 {
-		log.Tracef("dump data: %s", dump)
 		return fmt.Errorf("no items in dump")
 	}
 	log.Debugf("dump contains %d items", len(list))
@@ -260,107 +392,193 @@ func dumpConfig(handler probe.Handler, config *Config, viewType string) error {
 			err = errors.Wrapf(err, "failed to get model for key %v", item.Key)
 			log.Warn(err)
 			errs = append(errs, err)
+			config.Unknown = append(config.Unknown, item)
 			continue
 		}
 
-		switch model.Name() {
-		case linux_interfaces.ModelInterface.Name():
-			var value = LinuxInterface{KVData: item}
-			value.Value = linux_interfaces.ModelInterface.NewInstance().(*linux_interfaces.Interface)
-			if err := protojson.Unmarshal(item.Value, value.Value); err != nil {
+		decode := func(value proto.Message) bool {
+			if err := protojson.Unmarshal(item.Value, value); err != nil {
 				err = errors.Wrapf(err, "unmarshal value failed")
 				log.Warn(err)
 				errs = append(errs, err)
-				continue
+				config.Unknown = append(config.Unknown, item)
+				return false
+			}
+			return true
+		}
+
+		switch model.Name() {
+		case linux_interfaces.ModelInterface.Name():
+			var value = LinuxInterface{KVData: item, Value: &linux_interfaces.Interface{}}
+			if decode(value.Value) {
+				config.Linux.Interfaces = append(config.Linux.Interfaces, value)
 			}
-			config.Linux.Interfaces = append(config.Linux.Interfaces, value)
 
 		case linux_l3.ModelRoute.Name():
-			var value = LinuxRoute{KVData: item}
-			value.Value = linux_l3.ModelRoute.NewInstance().(*linux_l3.Route)
-			if err := protojson.Unmarshal(item.Value, value.Value); err != nil {
-				err = errors.Wrapf(err, "unmarshal value failed")
-				log.Warn(err)
-				errs = append(errs, err)
-				continue
+			var value = LinuxRoute{KVData: item, Value: &linux_l3.Route{}}
+			if decode(value.Value) {
+				config.Linux.Routes = append(config.Linux.Routes, value)
 			}
-			config.Linux.Routes = append(config.Linux.Routes, value)
 
 		case vpp_interfaces.ModelInterface.Name():
-			var value = VppInterface{KVData: item}
-			value.Value = vpp_interfaces.ModelInterface.NewInstance().(*vpp_interfaces.Interface)
-			if err := protojson.Unmarshal(item.Value, value.Value); err != nil {
-				err = errors.Wrapf(err, "unmarshal value failed")
-				log.Warn(err)
-				errs = append(errs, err)
-				continue
+			var value = VppInterface{KVData: item, Value: &vpp_interfaces.Interface{}}
+			if decode(value.Value) {
+				config.VPP.Interfaces = append(config.VPP.Interfaces, value)
 			}
-			config.VPP.Interfaces = append(config.VPP.Interfaces, value)
 
 		case vpp_l3.ModelRoute.Name():
-			var value = VppRoute{KVData: item}
-			value.Value = vpp_l3.ModelRoute.NewInstance().(*vpp_l3.Route)
-			if err := protojson.Unmarshal(item.Value, value.Value); err != nil {
-				err = errors.Wrapf(err, "unmarshal value failed")
-				log.Warn(err)
-				errs = append(errs, err)
-				continue
+			var value = VppRoute{KVData: item, Value: &vpp_l3.Route{}}
+			if decode(value.Value) {
+				config.VPP.Routes = append(config.VPP.Routes, value)
 			}
-			config.VPP.Routes = append(config.VPP.Routes, value)
 
 		case vpp_l2.ModelXConnectPair.Name():
-			var value = VppL2XConnect{KVData: item}
-			if err := json.Unmarshal(item.Value, &value.Value); err != nil {
-				err = errors.Wrapf(err, "unmarshal value failed")
-				log.Warn(err)
-				errs = append(errs, err)
-				continue
+			var value = VppL2XConnect{KVData: item, Value: &vpp_l2.XConnectPair{}}
+			if decode(value.Value) {
+				config.VPP.L2XConnects = append(config.VPP.L2XConnects, value)
 			}
-			config.VPP.L2XConnects = append(config.VPP.L2XConnects, value)
 
 		case vpp_ipsec.ModelTunnelProtection.Name():
-			var value = VppIPSecTunProtect{KVData: item}
-			if err := json.Unmarshal(item.Value, &value.Value); err != nil {
-				err = errors.Wrapf(err, "unmarshal value failed")
-				log.Warn(err)
-				errs = append(errs, err)
-				continue
+			var value = VppIPSecTunProtect{KVData: item, Value: &vpp_ipsec.TunnelProtection{}}
+			if decode(value.Value) {
+				config.VPP.IPSecTunProtects = append(config.VPP.IPSecTunProtects, value)
 			}
-			config.VPP.IPSecTunProtects = append(config.VPP.IPSecTunProtects, value)
 
 		case vpp_ipsec.ModelSecurityAssociation.Name():
-			var value = VppIPSecSA{KVData: item}
-			if err := json.Unmarshal(item.Value, &value.Value); err != nil {
-				err = errors.Wrapf(err, "unmarshal value failed")
-				log.Warn(err)
-				errs = append(errs, err)
-				continue
+			var value = VppIPSecSA{KVData: item, Value: &vpp_ipsec.SecurityAssociation{}}
+			if decode(value.Value) {
+				config.VPP.IPSecSAs = append(config.VPP.IPSecSAs, value)
 			}
-			config.VPP.IPSecSAs = append(config.VPP.IPSecSAs, value)
 
 		case vpp_ipsec.ModelSecurityPolicyDatabase.Name():
-			var value = VppIPSecSPD{KVData: item}
-			if err := json.Unmarshal(item.Value, &value.Value); err != nil {
-				err = errors.Wrapf(err, "unmarshal value failed")
-				log.Warn(err)
-				errs = append(errs, err)
-				continue
+			var value = VppIPSecSPD{KVData: item, Value: &vpp_ipsec.SecurityPolicyDatabase{}}
+			if decode(value.Value) {
+				config.VPP.IPSecSPDs = append(config.VPP.IPSecSPDs, value)
 			}
-			config.VPP.IPSecSPDs = append(config.VPP.IPSecSPDs, value)
 
 		case vpp_ipsec.ModelSecurityPolicy.Name():
-			var value = VppIPSecSP{KVData: item}
-			if err := json.Unmarshal(item.Value, &value.Value); err != nil {
-				err = errors.Wrapf(err, "unmarshal value failed")
-				log.Warn(err)
-				errs = append(errs, err)
-				continue
+			var value = VppIPSecSP{KVData: item, Value: &vpp_ipsec.SecurityPolicy{}}
+			if decode(value.Value) {
+				config.VPP.IPSecSPs = append(config.VPP.IPSecSPs, value)
+			}
+
+		case linux_l3.ModelARPEntry.Name():
+			var value = LinuxARPEntry{KVData: item, Value: &linux_l3.ARPEntry{}}
+			if decode(value.Value) {
+				config.Linux.ARPs = append(config.Linux.ARPs, value)
+			}
+
+		case vpp_l2.ModelBridgeDomain.Name():
+			var value = VppBridgeDomain{KVData: item, Value: &vpp_l2.BridgeDomain{}}
+			if decode(value.Value) {
+				config.VPP.BridgeDomains = append(config.VPP.BridgeDomains, value)
+			}
+
+		case vpp_l2.ModelFIBEntry.Name():
+			var value = VppL2FIB{KVData: item, Value: &vpp_l2.FIBEntry{}}
+			if decode(value.Value) {
+				config.VPP.L2FIBs = append(config.VPP.L2FIBs, value)
+			}
+
+		case vpp_l3.ModelARPEntry.Name():
+			var value = VppARPEntry{KVData: item, Value: &vpp_l3.ARPEntry{}}
+			if decode(value.Value) {
+				config.VPP.ARPs = append(config.VPP.ARPs, value)
+			}
+
+		case vpp_l3.ModelIPScanNeighbor.Name():
+			var value = VppIPScanNeighbor{KVData: item, Value: &vpp_l3.IPScanNeighbor{}}
+			if decode(value.Value) {
+				config.VPP.IPScanNeighbor = &value
+			}
+
+		case vpp_l3.ModelVrfTable.Name():
+			var value = VppVrfTable{KVData: item, Value: &vpp_l3.VrfTable{}}
+			if decode(value.Value) {
+				config.VPP.VrfTables = append(config.VPP.VrfTables, value)
+			}
+
+		case vpp_l3.ModelDHCPProxy.Name():
+			var value = VppDHCPProxy{KVData: item, Value: &vpp_l3.DHCPProxy{}}
+			if decode(value.Value) {
+				config.VPP.DHCPProxies = append(config.VPP.DHCPProxies, value)
+			}
+
+		case vpp_acl.ModelACL.Name():
+			var value = VppACL{KVData: item, Value: &vpp_acl.ACL{}}
+			if decode(value.Value) {
+				config.VPP.ACLs = append(config.VPP.ACLs, value)
+			}
+
+		case vpp_nat.ModelNat44Global.Name():
+			var value = VppNAT44Global{KVData: item, Value: &vpp_nat.Nat44Global{}}
+			if decode(value.Value) {
+				config.VPP.NAT44Global = &value
+			}
+
+		case vpp_nat.ModelDNat44.Name():
+			var value = VppDNAT44{KVData: item, Value: &vpp_nat.DNat44{}}
+			if decode(value.Value) {
+				config.VPP.DNAT44s = append(config.VPP.DNAT44s, value)
+			}
+
+		case vpp_nat.ModelNat44Interface.Name():
+			var value = VppNAT44Interface{KVData: item, Value: &vpp_nat.Nat44Interface{}}
+			if decode(value.Value) {
+				config.VPP.NAT44Interfaces = append(config.VPP.NAT44Interfaces, value)
+			}
+
+		case vpp_nat.ModelNat44AddressPool.Name():
+			var value = VppNAT44AddressPool{KVData: item, Value: &vpp_nat.Nat44AddressPool{}}
+			if decode(value.Value) {
+				config.VPP.NAT44AddressPools = append(config.VPP.NAT44AddressPools, value)
+			}
+
+		case vpp_punt.ModelToHost.Name():
+			var value = VppPuntToHost{KVData: item, Value: &vpp_punt.ToHost{}}
+			if decode(value.Value) {
+				config.VPP.PuntToHosts = append(config.VPP.PuntToHosts, value)
+			}
+
+		case vpp_punt.ModelIPRedirect.Name():
+			var value = VppPuntIPRedirect{KVData: item, Value: &vpp_punt.IPRedirect{}}
+			if decode(value.Value) {
+				config.VPP.PuntIPRedirects = append(config.VPP.PuntIPRedirects, value)
+			}
+
+		case vpp_punt.ModelException.Name():
+			var value = VppPuntException{KVData: item, Value: &vpp_punt.Exception{}}
+			if decode(value.Value) {
+				config.VPP.PuntExceptions = append(config.VPP.PuntExceptions, value)
+			}
+
+		case vpp_interfaces.ModelSpan.Name():
+			var value = VppSpan{KVData: item, Value: &vpp_interfaces.Span{}}
+			if decode(value.Value) {
+				config.VPP.Spans = append(config.VPP.Spans, value)
+			}
+
+		case vpp_stn.ModelRule.Name():
+			var value = VppSTNRule{KVData: item, Value: &vpp_stn.Rule{}}
+			if decode(value.Value) {
+				config.VPP.STNRules = append(config.VPP.STNRules, value)
+			}
+
+		case vpp_abf.ModelABF.Name():
+			var value = VppABF{KVData: item, Value: &vpp_abf.ABF{}}
+			if decode(value.Value) {
+				config.VPP.ABFs = append(config.VPP.ABFs, value)
+			}
+
+		case vpp_wg.ModelPeer.Name():
+			var value = VppWgPeer{KVData: item, Value: &vpp_wg.Peer{}}
+			if decode(value.Value) {
+				config.VPP.WgPeers = append(config.VPP.WgPeers, value)
 			}
-			config.VPP.IPSecSPs = append(config.VPP.IPSecSPs, value)
 
 		default:
-			err := errors.Errorf("unhandled model: %s, ignoring key %q", model.Name(), item.Key)
-			errs = append(errs, err)
+			log.Debugf("unhandled model: %s, keeping raw data of key %q", model.Name(), item.Key)
+			config.Unknown = append(config.Unknown, item)
 		}
 	}
 
@@ -371,11 +589,29 @@ func dumpConfig(handler probe.Handler, config *Config, viewType string) error {
 	return nil
 }
 
+// RetrieveMetadata adds metadata that is not provided by agent
+// (e.g. inode of memif socket) to config.
+func RetrieveMetadata(handler probe.Handler, config *Config) error {
+	return retrieveMetadata(handler, config)
+}
+
 func retrieveMetadata(handler probe.Handler, config *Config) error {
 	log := logrus.WithFields(map[string]interface{}{
 		"instance": handler.ID(),
 	})
 
+	// Linux routes and ARPs namespaces
+	for i, route := range config.Linux.Routes {
+		if iface := config.GetLinuxInterface(route.Value.GetOutgoingInterface()); iface != nil {
+			config.Linux.Routes[i].Namespace = iface.Value.GetNamespace()
+		}
+	}
+	for i, arp := range config.Linux.ARPs {
+		if iface := config.GetLinuxInterface(arp.Value.GetInterface()); iface != nil {
+			config.Linux.ARPs[i].Namespace = iface.Value.GetNamespace()
+		}
+	}
+
 	// VPP interfaces metadata
 	for i, iface := range config.VPP.Interfaces {
 
@@ -398,6 +634,12 @@ func retrieveMetadata(handler probe.Handler, config *Config) error {
 
 			config.VPP.Interfaces[i] = iface
 		}
+
+		// vhost-user interfaces are only found in config built from VPP
+		if socket, ok := iface.Metadata["VhostSocket"].(string); ok && socket != "" {
+			iface.Metadata["inode"] = getInodeForFile(handler, socket)
+			config.VPP.Interfaces[i] = iface
+		}
 	}
 
 	return nil
@@ -423,9 +665,9 @@ func FindL2XconnFor(iface string, l2XConnects []VppL2XConnect) *VppL2XConnect {
 }
 
 func FindIPSecTunProtectFor(iface string, tunProtects []VppIPSecTunProtect) *VppIPSecTunProtect {
-	for _, tp := range tunProtects {
-		if iface == tp.Value.Interface {
-			return &tp
+	for i, tp := range tunProtects {
+		if tp.Value != nil && iface == tp.Value.Interface {
+			return &tunProtects[i]
 		}
 	}
 	return nil
@@ -433,9 +675,9 @@ func FindIPSecTunProtectFor(iface string, tunProtects []VppIPSecTunProtect) *Vpp
 
 func findIPSecSPsFor(spdIdx uint32, config *Config) []*VppIPSecSP {
 	var sps []*VppIPSecSP
-	for _, tp := range config.VPP.IPSecSPs {
-		if spdIdx == tp.Value.SpdIndex {
-			sps = append(sps, &tp)
+	for i, tp := range config.VPP.IPSecSPs {
+		if tp.Value != nil && spdIdx == tp.Value.SpdIndex {
+			sps = append(sps, &config.VPP.IPSecSPs[i])
 		}
 	}
 	return sps
@@ -443,9 +685,9 @@ func findIPSecSPsFor(spdIdx uint32, config *Config) []*VppIPSecSP {
 
 func FindIPSecSPFor(iface string, config *Config) []*VppIPSecSP {
 	for _, tp := range config.VPP.IPSecSPDs {
-		for _, ifc := range tp.Value.Interfaces {
-			if iface == ifc.Name {
-				return findIPSecSPsFor(tp.Value.Index, config)
+		for _, ifc := range tp.Value.GetInterfaces() {
+			if iface == ifc.GetName() {
+				return findIPSecSPsFor(tp.Value.GetIndex(), config)
 			}
 		}
 	}
@@ -479,10 +721,11 @@ func HasAnyIPSecConfig(config *Config) bool {
 	return false
 }
 
+// FindIPSecSA returns SA with index or nil if not found.
 func FindIPSecSA(saIdx uint32, ipsecSas []VppIPSecSA) *VppIPSecSA {
-	for _, sa := range ipsecSas {
-		if saIdx == sa.Value.Index {
-			return &sa
+	for i, sa := range ipsecSas {
+		if sa.Value != nil && saIdx == sa.Value.Index {
+			return &ipsecSas[i]
 		}
 	}
 	return nil
@@ -6,20 +6,22 @@ import (
 
 // IPSecCorrelations define corrrelation maps for IPSec.
 type IPSecCorrelations struct {
-	SrcInstanceMap  map[string]*Instance
-	InSpSrcDestMap  map[string]map[string]VppIPSecSP
-	OutSpSrcDestMap map[string]map[string]VppIPSecSP
-	SpiInSrcDestMap map[uint32][]VppIPSecSP   // SPI key
-	SpiOutSrcDestMap map[uint32][]VppIPSecSP  // SPI key
+	SrcInstanceMap   map[string]*Instance
+	InSpSrcDestMap   map[string]map[string]VppIPSecSP
+	OutSpSrcDestMap  map[string]map[string]VppIPSecSP
+	SpiInSrcDestMap  map[uint32][]VppIPSecSP // SPI key
+	SpiOutSrcDestMap map[uint32][]VppIPSecSP // SPI key
+	// Pairing is a result of pairing outbound SAs with inbound SAs of peers.
+	Pairing *IPSecPairing
 }
 
 // CorrelateIPSec processes list of instances and returns IPSec correlations.
 func CorrelateIPSec(instances []*Instance) (*IPSecCorrelations, error) {
 	data := &IPSecCorrelations{
-		SrcInstanceMap:  map[string]*Instance{},
-		InSpSrcDestMap:  map[string]map[string]VppIPSecSP{},
-		OutSpSrcDestMap: map[string]map[string]VppIPSecSP{},
-		SpiInSrcDestMap: map[uint32][]VppIPSecSP{},
+		SrcInstanceMap:   map[string]*Instance{},
+		InSpSrcDestMap:   map[string]map[string]VppIPSecSP{},
+		OutSpSrcDestMap:  map[string]map[string]VppIPSecSP{},
+		SpiInSrcDestMap:  map[uint32][]VppIPSecSP{},
 		SpiOutSrcDestMap: map[uint32][]VppIPSecSP{},
 	}
 
@@ -30,21 +32,26 @@ func CorrelateIPSec(instances []*Instance) (*IPSecCorrelations, error) {
 		}
 		ipsecSPs := instance.Config.VPP.IPSecSPs
 		for _, sp := range ipsecSPs {
+			if sp.Value == nil {
+				continue
+			}
 			srcIp := sp.Value.LocalAddrStart
 			dstIp := sp.Value.RemoteAddrStart
 
 			data.SrcInstanceMap[srcIp] = instance
 
 			if sp.Value.IsOutbound {
-				outSa := FindIPSecSA(sp.Value.SaIndex, instance.Config.VPP.IPSecSAs)
-				data.SpiOutSrcDestMap[outSa.Value.Spi] = append(data.SpiOutSrcDestMap[outSa.Value.Spi], sp)
+				if outSa := FindIPSecSA(sp.Value.SaIndex, instance.Config.VPP.IPSecSAs); outSa != nil {
+					data.SpiOutSrcDestMap[outSa.Value.Spi] = append(data.SpiOutSrcDestMap[outSa.Value.Spi], sp)
+				}
 				if _, ok := data.OutSpSrcDestMap[srcIp]; !ok {
 					data.OutSpSrcDestMap[srcIp] = make(map[string]VppIPSecSP)
 				}
 				data.OutSpSrcDestMap[srcIp][dstIp] = sp
 			} else {
-				inSa := FindIPSecSA(sp.Value.SaIndex, instance.Config.VPP.IPSecSAs)
-				data.SpiInSrcDestMap[inSa.Value.Spi] = append(data.SpiInSrcDestMap[inSa.Value.Spi], sp)
+				if inSa := FindIPSecSA(sp.Value.SaIndex, instance.Config.VPP.IPSecSAs); inSa != nil {
+					data.SpiInSrcDestMap[inSa.Value.Spi] = append(data.SpiInSrcDestMap[inSa.Value.Spi], sp)
+				}
 				if _, ok := data.InSpSrcDestMap[srcIp]; !ok {
 					data.InSpSrcDestMap[srcIp] = make(map[string]VppIPSecSP)
 				}
@@ -53,7 +60,9 @@ func CorrelateIPSec(instances []*Instance) (*IPSecCorrelations, error) {
 		}
 	}
 
-	if len(data.InSpSrcDestMap) == 0 {
+	data.Pairing = PairIPSec(instances)
+
+	if len(data.InSpSrcDestMap) == 0 && len(data.Pairing.Tunnels) == 0 {
 		return nil, fmt.Errorf("no IPSec correlations found")
 	}
 
@@ -0,0 +1,233 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.ligato.io/vpp-agent/v3/plugins/kvscheduler/api"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/kvscheduler"
+)
+
+// DriftKind is a kind of difference between intended and actual config.
+type DriftKind string
+
+const (
+	// DriftMissing is a value intended by NB that is not found in SB.
+	DriftMissing DriftKind = "missing"
+	// DriftDifferent is a value with fields in SB that differ from NB.
+	DriftDifferent DriftKind = "different"
+	// DriftFailed is a value that failed to be applied.
+	DriftFailed DriftKind = "failed"
+)
+
+// Drift is a difference of single key between NB intent and SB state.
+type Drift struct {
+	Key    string
+	Kind   DriftKind
+	State  string   `json:",omitempty"`
+	Reason string   `json:",omitempty"`
+	Diffs  []string `json:",omitempty"`
+}
+
+// DetectDrift compares values intended by NB (from cached view) with values
+// dumped from SB and value statuses of kvscheduler. It returns list of keys
+// that are missing, different or failed in VPP sorted by key.
+func DetectDrift(c *Client) ([]Drift, error) {
+	log := logrus.WithFields(map[string]interface{}{
+		"instance": c.handler.ID(),
+	})
+
+	cached, err := dumpKVData(c, "cached")
+	if err != nil {
+		return nil, fmt.Errorf("dumping cached view failed: %w", err)
+	}
+	sb, err := dumpKVData(c, "SB")
+	if err != nil {
+		return nil, fmt.Errorf("dumping SB view failed: %w", err)
+	}
+	statuses, err := dumpValueStatuses(c)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("comparing %d cached values with %d SB values (%d statuses)", len(cached), len(sb), len(statuses))
+
+	return compareViews(cached, sb, statuses), nil
+}
+
+func compareViews(cached, sb []KVData, statuses []*kvscheduler.BaseValueStatus) []Drift {
+	actual := make(map[string]KVData, len(sb))
+	for _, kv := range sb {
+		actual[kv.Key] = kv
+	}
+	status := map[string]*kvscheduler.ValueStatus{}
+	for _, base := range statuses {
+		if v := base.GetValue(); v != nil {
+			status[v.GetKey()] = v
+		}
+		for _, derived := range base.GetDerivedValues() {
+			status[derived.GetKey()] = derived
+		}
+	}
+
+	var drifts []Drift
+	reported := map[string]bool{}
+
+	for _, intent := range cached {
+		if api.ValueOrigin(intent.Origin) != api.FromNB {
+			continue
+		}
+		reported[intent.Key] = true
+
+		st := status[intent.Key]
+		if isProblemState(st.GetState()) {
+			drifts = append(drifts, statusDrift(st))
+			continue
+		}
+		if st.GetState() == kvscheduler.ValueState_UNIMPLEMENTED {
+			continue
+		}
+		kv, ok := actual[intent.Key]
+		if !ok {
+			drifts = append(drifts, Drift{
+				Key:    intent.Key,
+				Kind:   DriftMissing,
+				State:  stateString(st),
+				Reason: "not found in SB dump",
+			})
+			continue
+		}
+		diffs, err := diffJSON(intent.Value, kv.Value)
+		if err != nil {
+			logrus.Debugf("comparing values of key %q failed: %v", intent.Key, err)
+			continue
+		}
+		if len(diffs) > 0 {
+			drifts = append(drifts, Drift{
+				Key:   intent.Key,
+				Kind:  DriftDifferent,
+				State: stateString(st),
+				Diffs: diffs,
+			})
+		}
+	}
+
+	// derived values are not part of NB dump
+	for key, st := range status {
+		if reported[key] || !isProblemState(st.GetState()) {
+			continue
+		}
+		drifts = append(drifts, statusDrift(st))
+	}
+
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].Key < drifts[j].Key
+	})
+	return drifts
+}
+
+func isProblemState(state kvscheduler.ValueState) bool {
+	switch state {
+	case kvscheduler.ValueState_MISSING,
+		kvscheduler.ValueState_PENDING,
+		kvscheduler.ValueState_INVALID,
+		kvscheduler.ValueState_FAILED,
+		kvscheduler.ValueState_RETRYING:
+		return true
+	}
+	return false
+}
+
+func statusDrift(st *kvscheduler.ValueStatus) Drift {
+	kind := DriftFailed
+	if st.GetState() == kvscheduler.ValueState_MISSING {
+		kind = DriftMissing
+	}
+	reason := st.GetError()
+	if reason == "" && len(st.GetDetails()) > 0 {
+		reason = "waiting for " + strings.Join(st.GetDetails(), ", ")
+	}
+	return Drift{
+		Key:    st.GetKey(),
+		Kind:   kind,
+		State:  stateString(st),
+		Reason: reason,
+	}
+}
+
+func stateString(st *kvscheduler.ValueStatus) string {
+	if st == nil {
+		return ""
+	}
+	return st.GetState().String()
+}
+
+// diffJSON returns paths of fields set in intent that differ in actual.
+func diffJSON(intent, actual json.RawMessage) ([]string, error) {
+	var a, b interface{}
+	if err := json.Unmarshal(intent, &a); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(actual, &b); err != nil {
+		return nil, err
+	}
+	var diffs []string
+	diffValues("", a, b, &diffs)
+	return diffs, nil
+}
+
+func diffValues(path string, intent, actual interface{}, diffs *[]string) {
+	switch x := intent.(type) {
+	case map[string]interface{}:
+		y, ok := actual.(map[string]interface{})
+		if !ok {
+			*diffs = append(*diffs, fmt.Sprintf("%s: %s != %s", pathOrRoot(path), formatJSON(intent), formatJSON(actual)))
+			return
+		}
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			diffValues(path+"."+k, x[k], y[k], diffs)
+		}
+	case []interface{}:
+		y, ok := actual.([]interface{})
+		if !ok || len(x) != len(y) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: %s != %s", pathOrRoot(path), formatJSON(intent), formatJSON(actual)))
+			return
+		}
+		for i := range x {
+			diffValues(fmt.Sprintf("%s[%d]", path, i), x[i], y[i], diffs)
+		}
+	default:
+		if !reflect.DeepEqual(intent, actual) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: %s != %s", pathOrRoot(path), formatJSON(intent), formatJSON(actual)))
+		}
+	}
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
+
+func formatJSON(v interface{}) string {
+	if v == nil {
+		return "<unset>"
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSpace(buf.String())
+}
@@ -0,0 +1,131 @@
+package agent
+
+import (
+	"reflect"
+	"testing"
+
+	"go.ligato.io/vpp-agent/v3/plugins/kvscheduler/api"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/kvscheduler"
+)
+
+func TestDiffJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		intent string
+		actual string
+		want   []string
+	}{
+		{
+			name:   "equal",
+			intent: `{"name":"tap0","enabled":true}`,
+			actual: `{"name":"tap0","enabled":true,"physAddress":"02:00:00:00:00:01"}`,
+		},
+		{
+			name:   "different field",
+			intent: `{"name":"tap0","mtu":1500}`,
+			actual: `{"name":"tap0","mtu":9000}`,
+			want:   []string{".mtu: 1500 != 9000"},
+		},
+		{
+			name:   "missing nested field",
+			intent: `{"name":"tap0","tap":{"version":2}}`,
+			actual: `{"name":"tap0"}`,
+			want:   []string{`.tap: {"version":2} != <unset>`},
+		},
+		{
+			name:   "different list",
+			intent: `{"ipAddresses":["10.0.0.1/24"]}`,
+			actual: `{"ipAddresses":["10.0.0.1/24","10.0.0.2/24"]}`,
+			want:   []string{`.ipAddresses: ["10.0.0.1/24"] != ["10.0.0.1/24","10.0.0.2/24"]`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := diffJSON([]byte(tt.intent), []byte(tt.actual))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffJSON() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareViews(t *testing.T) {
+	nb := func(key, value string) KVData {
+		return KVData{Key: key, Value: []byte(value), Origin: ValueOrigin(api.FromNB)}
+	}
+	sb := func(key, value string) KVData {
+		return KVData{Key: key, Value: []byte(value), Origin: ValueOrigin(api.FromSB)}
+	}
+	status := func(key string, state kvscheduler.ValueState, derived ...*kvscheduler.ValueStatus) *kvscheduler.BaseValueStatus {
+		return &kvscheduler.BaseValueStatus{
+			Value:         &kvscheduler.ValueStatus{Key: key, State: state},
+			DerivedValues: derived,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		cached   []KVData
+		sb       []KVData
+		statuses []*kvscheduler.BaseValueStatus
+		want     []Drift
+	}{
+		{
+			name:     "in sync",
+			cached:   []KVData{nb("config/vpp/v2/interfaces/tap0", `{"name":"tap0","mtu":1500}`)},
+			sb:       []KVData{sb("config/vpp/v2/interfaces/tap0", `{"name":"tap0","mtu":1500}`)},
+			statuses: []*kvscheduler.BaseValueStatus{status("config/vpp/v2/interfaces/tap0", kvscheduler.ValueState_CONFIGURED)},
+		},
+		{
+			name:   "added in SB",
+			cached: []KVData{sb("config/vpp/v2/interfaces/local0", `{"name":"local0"}`)},
+			sb: []KVData{
+				sb("config/vpp/v2/interfaces/local0", `{"name":"local0"}`),
+				sb("config/vpp/v2/interfaces/loop0", `{"name":"loop0"}`),
+			},
+		},
+		{
+			name:     "removed from SB",
+			cached:   []KVData{nb("config/vpp/v2/interfaces/tap0", `{"name":"tap0"}`)},
+			statuses: []*kvscheduler.BaseValueStatus{status("config/vpp/v2/interfaces/tap0", kvscheduler.ValueState_CONFIGURED)},
+			want: []Drift{
+				{Key: "config/vpp/v2/interfaces/tap0", Kind: DriftMissing, State: "CONFIGURED", Reason: "not found in SB dump"},
+			},
+		},
+		{
+			name:   "changed in SB",
+			cached: []KVData{nb("config/vpp/v2/interfaces/tap0", `{"name":"tap0","mtu":1500}`)},
+			sb:     []KVData{sb("config/vpp/v2/interfaces/tap0", `{"name":"tap0","mtu":9000}`)},
+			want: []Drift{
+				{Key: "config/vpp/v2/interfaces/tap0", Kind: DriftDifferent, Diffs: []string{".mtu: 1500 != 9000"}},
+			},
+		},
+		{
+			name:   "failed and pending",
+			cached: []KVData{nb("config/vpp/v2/interfaces/tap0", `{"name":"tap0"}`)},
+			statuses: []*kvscheduler.BaseValueStatus{
+				{
+					Value: &kvscheduler.ValueStatus{Key: "config/vpp/v2/interfaces/tap0", State: kvscheduler.ValueState_FAILED, Error: "tap create failed"},
+				},
+				status("config/vpp/v2/interfaces/memif0", kvscheduler.ValueState_CONFIGURED,
+					&kvscheduler.ValueStatus{Key: "vpp/interface/memif0/address/10.0.0.1/24", State: kvscheduler.ValueState_PENDING, Details: []string{"vrf-table"}},
+				),
+			},
+			want: []Drift{
+				{Key: "config/vpp/v2/interfaces/tap0", Kind: DriftFailed, State: "FAILED", Reason: "tap create failed"},
+				{Key: "vpp/interface/memif0/address/10.0.0.1/24", Kind: DriftFailed, State: "PENDING", Reason: "waiting for vrf-table"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := compareViews(tt.cached, tt.sb, tt.statuses)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("compareViews()\n got: %+v\nwant: %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package agent
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	vpp_ipsec "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/ipsec"
+)
+
+// IPSecVerdict is a summary verdict for IPSec tunnel.
+type IPSecVerdict string
+
+const (
+	// IPSecOK is a tunnel with both SAs matching.
+	IPSecOK IPSecVerdict = "ok"
+	// IPSecMismatch is a tunnel with SAs paired by SPI that differ.
+	IPSecMismatch IPSecVerdict = "mismatch"
+	// IPSecUnpaired is a tunnel with SPI without counterpart.
+	IPSecUnpaired IPSecVerdict = "unpaired"
+)
+
+// IPSecSAEnd is one end of IPSec tunnel.
+type IPSecSAEnd struct {
+	Instance  string
+	SaIndex   uint32
+	Spi       uint32
+	Protocol  string
+	CryptoAlg string
+	IntegAlg  string
+	TunnelSrc string `json:",omitempty"`
+	TunnelDst string `json:",omitempty"`
+
+	sa *vpp_ipsec.SecurityAssociation
+}
+
+// IPSecTunnel is an outbound SA paired with inbound SA of the peer.
+type IPSecTunnel struct {
+	Spi     uint32
+	Out     *IPSecSAEnd `json:",omitempty"`
+	In      *IPSecSAEnd `json:",omitempty"`
+	Verdict IPSecVerdict
+	Issues  []string `json:",omitempty"`
+}
+
+// IPSecPolicyIssue is a security policy that cannot be used.
+type IPSecPolicyIssue struct {
+	Instance string
+	SpdIndex uint32
+	SaIndex  uint32
+	Local    string
+	Remote   string
+	Outbound bool
+	Issue    string
+}
+
+// IPSecPairing is a result of pairing SAs across instances.
+type IPSecPairing struct {
+	Tunnels  []IPSecTunnel
+	Policies []IPSecPolicyIssue `json:",omitempty"`
+}
+
+// PairIPSec matches each outbound SA with inbound SA with the same SPI on
+// the peer instance and reports what differs between them. Keys are only
+// compared by hash. SAs used in both directions are paired as outbound.
+func PairIPSec(instances []*Instance) *IPSecPairing {
+	pairing := &IPSecPairing{}
+
+	var outbound, inbound []*IPSecSAEnd
+	for _, instance := range instances {
+		if instance == nil || !HasAnyIPSecConfig(instance.Config) {
+			continue
+		}
+		out, in, issues := collectIPSecSAs(instance)
+		outbound = append(outbound, out...)
+		inbound = append(inbound, in...)
+		pairing.Policies = append(pairing.Policies, issues...)
+	}
+
+	paired := map[*IPSecSAEnd]bool{}
+	for _, out := range outbound {
+		tunnel := IPSecTunnel{Spi: out.Spi, Out: out}
+		if in := findPeerSA(out, inbound, paired); in != nil {
+			paired[in] = true
+			tunnel.In = in
+			tunnel.Issues = compareSAs(out, in)
+		} else {
+			tunnel.Issues = []string{fmt.Sprintf("SPI %d has no inbound SA on peer", out.Spi)}
+		}
+		pairing.Tunnels = append(pairing.Tunnels, tunnel)
+	}
+	for _, in := range inbound {
+		if paired[in] {
+			continue
+		}
+		pairing.Tunnels = append(pairing.Tunnels, IPSecTunnel{
+			Spi:    in.Spi,
+			In:     in,
+			Issues: []string{fmt.Sprintf("SPI %d has no outbound SA on peer", in.Spi)},
+		})
+	}
+
+	for i := range pairing.Tunnels {
+		t := &pairing.Tunnels[i]
+		switch {
+		case t.In == nil || t.Out == nil:
+			t.Verdict = IPSecUnpaired
+		case len(t.Issues) > 0:
+			t.Verdict = IPSecMismatch
+		default:
+			t.Verdict = IPSecOK
+		}
+	}
+	sort.SliceStable(pairing.Tunnels, func(i, j int) bool {
+		return pairing.Tunnels[i].Spi < pairing.Tunnels[j].Spi
+	})
+
+	return pairing
+}
+
+// collectIPSecSAs returns SAs of instance by direction in which they are
+// used by security policies or tunnel protections.
+func collectIPSecSAs(instance *Instance) (out, in []*IPSecSAEnd, issues []IPSecPolicyIssue) {
+	config := instance.Config
+	ends := map[uint32]*IPSecSAEnd{}
+	isOut := map[uint32]bool{}
+	isIn := map[uint32]bool{}
+
+	use := func(saIdx uint32, outbound bool) bool {
+		sa := FindIPSecSA(saIdx, config.VPP.IPSecSAs)
+		if sa == nil {
+			return false
+		}
+		if _, ok := ends[saIdx]; !ok {
+			ends[saIdx] = newIPSecSAEnd(instance.ID(), sa.Value)
+		}
+		if outbound {
+			isOut[saIdx] = true
+		} else {
+			isIn[saIdx] = true
+		}
+		return true
+	}
+
+	for _, sp := range config.VPP.IPSecSPs {
+		if sp.Value == nil || sp.Value.Action != vpp_ipsec.SecurityPolicy_PROTECT {
+			continue
+		}
+		if !use(sp.Value.SaIndex, sp.Value.IsOutbound) {
+			issues = append(issues, IPSecPolicyIssue{
+				Instance: instance.ID(),
+				SpdIndex: sp.Value.SpdIndex,
+				SaIndex:  sp.Value.SaIndex,
+				Local:    sp.Value.LocalAddrStart,
+				Remote:   sp.Value.RemoteAddrStart,
+				Outbound: sp.Value.IsOutbound,
+				Issue:    fmt.Sprintf("SA %d not found", sp.Value.SaIndex),
+			})
+		}
+	}
+	for _, tp := range config.VPP.IPSecTunProtects {
+		if tp.Value == nil {
+			continue
+		}
+		for _, idx := range tp.Value.SaOut {
+			use(idx, true)
+		}
+		for _, idx := range tp.Value.SaIn {
+			use(idx, false)
+		}
+	}
+
+	idxs := make([]uint32, 0, len(ends))
+	for idx := range ends {
+		idxs = append(idxs, idx)
+	}
+	sort.Slice(idxs, func(i, j int) bool { return idxs[i] < idxs[j] })
+	for _, idx := range idxs {
+		if isOut[idx] {
+			out = append(out, ends[idx])
+		} else if isIn[idx] {
+			in = append(in, ends[idx])
+		}
+	}
+	return out, in, issues
+}
+
+func newIPSecSAEnd(instance string, sa *vpp_ipsec.SecurityAssociation) *IPSecSAEnd {
+	return &IPSecSAEnd{
+		Instance:  instance,
+		SaIndex:   sa.GetIndex(),
+		Spi:       sa.GetSpi(),
+		Protocol:  sa.GetProtocol().String(),
+		CryptoAlg: sa.GetCryptoAlg().String(),
+		IntegAlg:  sa.GetIntegAlg().String(),
+		TunnelSrc: sa.GetTunnelSrcAddr(),
+		TunnelDst: sa.GetTunnelDstAddr(),
+		sa:        sa,
+	}
+}
+
+// findPeerSA returns unpaired inbound SA with SPI of out on other instance,
+// preferring SA with matching tunnel endpoints.
+func findPeerSA(out *IPSecSAEnd, inbound []*IPSecSAEnd, paired map[*IPSecSAEnd]bool) *IPSecSAEnd {
+	var best *IPSecSAEnd
+	for _, in := range inbound {
+		if in.Spi != out.Spi || in.Instance == out.Instance || paired[in] {
+			continue
+		}
+		if in.TunnelSrc == out.TunnelSrc && in.TunnelDst == out.TunnelDst {
+			return in
+		}
+		if best == nil {
+			best = in
+		}
+	}
+	return best
+}
+
+// compareSAs returns differences between outbound SA and inbound SA of peer.
+func compareSAs(out, in *IPSecSAEnd) []string {
+	var issues []string
+	if out.Protocol != in.Protocol {
+		issues = append(issues, fmt.Sprintf("protocol mismatch: %s != %s", out.Protocol, in.Protocol))
+	}
+	if out.CryptoAlg != in.CryptoAlg {
+		issues = append(issues, fmt.Sprintf("crypto algorithm mismatch: %s != %s", out.CryptoAlg, in.CryptoAlg))
+	} else if keyHash(out.sa.GetCryptoKey()) != keyHash(in.sa.GetCryptoKey()) {
+		issues = append(issues, "crypto key mismatch")
+	}
+	if out.IntegAlg != in.IntegAlg {
+		issues = append(issues, fmt.Sprintf("integrity algorithm mismatch: %s != %s", out.IntegAlg, in.IntegAlg))
+	} else if keyHash(out.sa.GetIntegKey()) != keyHash(in.sa.GetIntegKey()) {
+		issues = append(issues, "integrity key mismatch")
+	}
+	if out.TunnelSrc != in.TunnelSrc || out.TunnelDst != in.TunnelDst {
+		issues = append(issues, fmt.Sprintf("tunnel asymmetry: %s -> %s != %s -> %s",
+			out.TunnelSrc, out.TunnelDst, in.TunnelSrc, in.TunnelDst))
+	}
+	return issues
+}
+
+// keyHash returns hash of key so keys can be compared without keeping them.
+func keyHash(key string) [sha256.Size]byte {
+	key = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(key), "0x"))
+	return sha256.Sum256([]byte(key))
+}
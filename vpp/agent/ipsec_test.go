@@ -0,0 +1,114 @@
+package agent
+
+import (
+	"reflect"
+	"testing"
+
+	vpp_ipsec "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/ipsec"
+
+	"go.ligato.io/vpp-probe/probe"
+)
+
+type idHandler struct {
+	probe.Handler
+	id string
+}
+
+func (h *idHandler) ID() string { return h.id }
+
+func ipsecInstance(id string, sas []*vpp_ipsec.SecurityAssociation, sps []*vpp_ipsec.SecurityPolicy) *Instance {
+	config := &Config{}
+	for _, sa := range sas {
+		config.VPP.IPSecSAs = append(config.VPP.IPSecSAs, VppIPSecSA{Value: sa})
+	}
+	for _, sp := range sps {
+		config.VPP.IPSecSPs = append(config.VPP.IPSecSPs, VppIPSecSP{Value: sp})
+	}
+	return &Instance{handler: &idHandler{id: id}, Config: config}
+}
+
+func ipsecSA(idx, spi uint32, key, src, dst string) *vpp_ipsec.SecurityAssociation {
+	return &vpp_ipsec.SecurityAssociation{
+		Index:         idx,
+		Spi:           spi,
+		CryptoAlg:     vpp_ipsec.CryptoAlg_AES_CBC_128,
+		CryptoKey:     key,
+		IntegAlg:      vpp_ipsec.IntegAlg_SHA1_96,
+		IntegKey:      "0x1111",
+		TunnelSrcAddr: src,
+		TunnelDstAddr: dst,
+	}
+}
+
+func ipsecSP(saIdx uint32, outbound bool) *vpp_ipsec.SecurityPolicy {
+	return &vpp_ipsec.SecurityPolicy{
+		SaIndex:    saIdx,
+		IsOutbound: outbound,
+		Action:     vpp_ipsec.SecurityPolicy_PROTECT,
+	}
+}
+
+func TestPairIPSec(t *testing.T) {
+	a := ipsecInstance("a",
+		[]*vpp_ipsec.SecurityAssociation{
+			ipsecSA(1, 100, "0xAAAA", "10.0.0.1", "10.0.0.2"),
+			ipsecSA(2, 200, "0xbbbb", "10.0.0.2", "10.0.0.1"),
+			ipsecSA(3, 300, "0xcccc", "10.0.0.1", "10.0.0.2"),
+		},
+		[]*vpp_ipsec.SecurityPolicy{ipsecSP(1, true), ipsecSP(2, false), ipsecSP(3, true), ipsecSP(9, false)},
+	)
+	b := ipsecInstance("b",
+		[]*vpp_ipsec.SecurityAssociation{
+			ipsecSA(1, 100, "0xaaaa", "10.0.0.1", "10.0.0.2"),
+			ipsecSA(2, 200, "0xdddd", "10.0.0.1", "10.0.0.2"),
+		},
+		[]*vpp_ipsec.SecurityPolicy{ipsecSP(1, false), ipsecSP(2, true)},
+	)
+
+	pairing := PairIPSec([]*Instance{a, b, nil})
+
+	type result struct {
+		Spi     uint32
+		Verdict IPSecVerdict
+		Issues  []string
+	}
+	var got []result
+	for _, tun := range pairing.Tunnels {
+		got = append(got, result{tun.Spi, tun.Verdict, tun.Issues})
+	}
+	want := []result{
+		{100, IPSecOK, nil},
+		{200, IPSecMismatch, []string{
+			"crypto key mismatch",
+			"tunnel asymmetry: 10.0.0.1 -> 10.0.0.2 != 10.0.0.2 -> 10.0.0.1",
+		}},
+		{300, IPSecUnpaired, []string{"SPI 300 has no inbound SA on peer"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PairIPSec() tunnels\n got: %+v\nwant: %+v", got, want)
+	}
+	if len(pairing.Policies) != 1 || pairing.Policies[0].SaIndex != 9 {
+		t.Errorf("expected policy issue for SA 9, got %+v", pairing.Policies)
+	}
+}
+
+func TestPairIPSecSameInstance(t *testing.T) {
+	a := ipsecInstance("a",
+		[]*vpp_ipsec.SecurityAssociation{
+			ipsecSA(1, 100, "0xaaaa", "10.0.0.1", "10.0.0.2"),
+			ipsecSA(2, 100, "0xaaaa", "10.0.0.1", "10.0.0.2"),
+		},
+		[]*vpp_ipsec.SecurityPolicy{ipsecSP(1, true), ipsecSP(2, false)},
+	)
+
+	pairing := PairIPSec([]*Instance{a})
+
+	if len(pairing.Tunnels) != 2 {
+		t.Fatalf("expected 2 tunnels, got %+v", pairing.Tunnels)
+	}
+	for _, tun := range pairing.Tunnels {
+		if tun.Verdict != IPSecUnpaired {
+			t.Errorf("SA pair on same instance: verdict = %v, want %v", tun.Verdict, IPSecUnpaired)
+		}
+	}
+}
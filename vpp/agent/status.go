@@ -0,0 +1,203 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Operational states of agent and its plugins.
+const (
+	StateInit  = "INIT"
+	StateOK    = "OK"
+	StateError = "ERROR"
+)
+
+var operationalStates = []string{StateInit, StateOK, StateError}
+
+// kvStorePlugins are names of plugins providing connection to KV store.
+var kvStorePlugins = []string{"etcd", "redis", "consul", "bolt"}
+
+// AgentStatus is an overall status of agent.
+type AgentStatus struct {
+	BuildVersion string    `json:"build_version"`
+	BuildDate    string    `json:"build_date"`
+	CommitHash   string    `json:"commit_hash,omitempty"`
+	State        string    `json:"state,omitempty"`
+	StartTime    time.Time `json:"start_time,omitempty"`
+	LastChange   time.Time `json:"last_change,omitempty"`
+	LastUpdate   time.Time `json:"last_update,omitempty"`
+}
+
+// PluginStatus is a status of agent plugin.
+type PluginStatus struct {
+	Name       string
+	State      string
+	Error      string    `json:",omitempty"`
+	LastChange time.Time `json:",omitempty"`
+}
+
+// IsOK returns true if plugin state is OK.
+func (p PluginStatus) IsOK() bool {
+	return p.State == StateOK
+}
+
+// IsReady returns true if agent and all of its plugins are OK.
+func (info *Info) IsReady() bool {
+	if info.Status.State != "" && info.Status.State != StateOK {
+		return false
+	}
+	return len(info.NotReadyPlugins()) == 0
+}
+
+// NotReadyPlugins returns plugins with other than OK state.
+func (info *Info) NotReadyPlugins() []PluginStatus {
+	var list []PluginStatus
+	for _, p := range info.Plugins {
+		if !p.IsOK() {
+			list = append(list, p)
+		}
+	}
+	return list
+}
+
+type rawAgentStatus struct {
+	BuildVersion string    `json:"build_version"`
+	BuildDate    string    `json:"build_date"`
+	CommitHash   string    `json:"commit_hash"`
+	State        enumValue `json:"state"`
+	StartTime    unixTime  `json:"start_time"`
+	LastChange   unixTime  `json:"last_change"`
+	LastUpdate   unixTime  `json:"last_update"`
+}
+
+type rawPluginStatus struct {
+	State      enumValue `json:"state"`
+	Error      string    `json:"error"`
+	LastChange unixTime  `json:"last_change"`
+}
+
+// unixTime is a time encoded as unix seconds (number or string) or RFC3339.
+type unixTime time.Time
+
+func (t *unixTime) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" || s == "0" {
+		return nil
+	}
+	if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+		*t = unixTime(time.Unix(sec, 0))
+		return nil
+	}
+	x, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return err
+	}
+	*t = unixTime(x)
+	return nil
+}
+
+// lookupKey returns first value found in m for keys.
+func lookupKey(m map[string]json.RawMessage, keys ...string) (json.RawMessage, bool) {
+	for _, k := range keys {
+		if v, ok := m[k]; ok && string(v) != "null" {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// parseInfo parses agent status either as printed by agentctl (nested in
+// Status) or as returned by probe endpoints of REST API.
+func parseInfo(data []byte) (*Info, error) {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
+		return nil, err
+	}
+	agentData := json.RawMessage(data)
+	nested := top
+	if v, ok := lookupKey(top, "Status", "status", "AgentStatus", "agent_status"); ok {
+		agentData = v
+		nested = nil
+		_ = json.Unmarshal(v, &nested)
+	}
+
+	var raw rawAgentStatus
+	if err := json.Unmarshal(agentData, &raw); err != nil {
+		return nil, fmt.Errorf("agent status: %w", err)
+	}
+	info := &Info{
+		Status: AgentStatus{
+			BuildVersion: raw.BuildVersion,
+			BuildDate:    raw.BuildDate,
+			CommitHash:   raw.CommitHash,
+			State:        stateName(raw.State),
+			StartTime:    time.Time(raw.StartTime),
+			LastChange:   time.Time(raw.LastChange),
+			LastUpdate:   time.Time(raw.LastUpdate),
+		},
+	}
+
+	pluginKeys := []string{"PluginStatus", "plugin_status", "Plugins", "plugins"}
+	pluginData, ok := lookupKey(top, pluginKeys...)
+	if !ok {
+		pluginData, ok = lookupKey(nested, pluginKeys...)
+	}
+	if ok {
+		var plugins map[string]rawPluginStatus
+		if err := json.Unmarshal(pluginData, &plugins); err != nil {
+			return nil, fmt.Errorf("plugin status: %w", err)
+		}
+		for name, p := range plugins {
+			info.Plugins = append(info.Plugins, PluginStatus{
+				Name:       name,
+				State:      stateName(p.State),
+				Error:      p.Error,
+				LastChange: time.Time(p.LastChange),
+			})
+		}
+		sort.Slice(info.Plugins, func(i, j int) bool {
+			return info.Plugins[i].Name < info.Plugins[j].Name
+		})
+	}
+
+	for i, p := range info.Plugins {
+		if p.Error != "" {
+			info.Errors = append(info.Errors, fmt.Sprintf("%s: %s", p.Name, p.Error))
+		}
+		if info.KVStore == nil && isKVStorePlugin(p.Name) {
+			info.KVStore = &info.Plugins[i]
+		}
+	}
+	return info, nil
+}
+
+func stateName(v enumValue) string {
+	if v == "" {
+		return ""
+	}
+	return strings.ToUpper(enumName(v, operationalStates))
+}
+
+func isKVStorePlugin(name string) bool {
+	name = strings.ToLower(name)
+	for _, kv := range kvStorePlugins {
+		if strings.Contains(name, kv) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastResync returns start time of the last resync transaction.
+func lastResync(txns []Txn) time.Time {
+	for i := len(txns) - 1; i >= 0; i-- {
+		if txns[i].Resync != "" {
+			return txns[i].Start
+		}
+	}
+	return time.Time{}
+}
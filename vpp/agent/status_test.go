@@ -0,0 +1,69 @@
+package agent
+
+import (
+	"testing"
+)
+
+func TestParseInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		wantVersion string
+		wantState   string
+		wantPlugins int
+		wantReady   bool
+		wantKVStore string
+	}{
+		{
+			name:        "agentctl nested",
+			data:        `{"Status": {"build_version": "v3.4.0", "state": 1, "start_time": 1600000000, "plugin_status": {"etcd": {"state": 1}, "govpp": {"state": 1}}}}`,
+			wantVersion: "v3.4.0",
+			wantState:   StateOK,
+			wantPlugins: 2,
+			wantReady:   true,
+			wantKVStore: "etcd",
+		},
+		{
+			name:        "readiness flat",
+			data:        `{"build_version": "v3.4.0", "state": "ERROR", "plugin_status": {"etcd": {"state": "ERROR", "error": "context deadline exceeded"}}}`,
+			wantVersion: "v3.4.0",
+			wantState:   StateError,
+			wantPlugins: 1,
+			wantReady:   false,
+			wantKVStore: "etcd",
+		},
+		{
+			name:        "without plugins",
+			data:        `{"Status": {"build_version": "v3.2.0"}}`,
+			wantVersion: "v3.2.0",
+			wantReady:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := parseInfo([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("parseInfo() error = %v", err)
+			}
+			if info.Status.BuildVersion != tt.wantVersion {
+				t.Errorf("BuildVersion = %q, want %q", info.Status.BuildVersion, tt.wantVersion)
+			}
+			if info.Status.State != tt.wantState {
+				t.Errorf("State = %q, want %q", info.Status.State, tt.wantState)
+			}
+			if len(info.Plugins) != tt.wantPlugins {
+				t.Errorf("got %d plugins, want %d", len(info.Plugins), tt.wantPlugins)
+			}
+			if info.IsReady() != tt.wantReady {
+				t.Errorf("IsReady() = %v, want %v", info.IsReady(), tt.wantReady)
+			}
+			var kvStore string
+			if info.KVStore != nil {
+				kvStore = info.KVStore.Name
+			}
+			if kvStore != tt.wantKVStore {
+				t.Errorf("KVStore = %q, want %q", kvStore, tt.wantKVStore)
+			}
+		})
+	}
+}
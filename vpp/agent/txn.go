@@ -0,0 +1,171 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Txn is a transaction recorded by kvscheduler.
+type Txn struct {
+	SeqNum      uint64
+	Type        string
+	Resync      string `json:",omitempty"`
+	Description string `json:",omitempty"`
+	Start       time.Time
+	Stop        time.Time
+	// Values is a number of values changed by transaction.
+	Values int
+	Ops    []TxnOp  `json:",omitempty"`
+	Errors []string `json:",omitempty"`
+}
+
+// TxnOp is an operation executed in transaction.
+type TxnOp struct {
+	Operation string
+	Key       string
+	NewState  string `json:",omitempty"`
+	Error     string `json:",omitempty"`
+	IsDerived bool   `json:",omitempty"`
+	IsRetry   bool   `json:",omitempty"`
+}
+
+// LastError returns the last error of transactions or empty string.
+func LastError(txns []Txn) string {
+	for i := len(txns) - 1; i >= 0; i-- {
+		if n := len(txns[i].Errors); n > 0 {
+			return txns[i].Errors[n-1]
+		}
+	}
+	return ""
+}
+
+// RetrieveTxnHistory returns kvscheduler transactions started after since
+// (all if zero) ordered by sequence number.
+func RetrieveTxnHistory(c *Client, since time.Time) ([]Txn, error) {
+	return c.TxnHistory(since)
+}
+
+func filterTxnsSince(txns []Txn, since time.Time) []Txn {
+	if since.IsZero() {
+		return txns
+	}
+	filtered := txns[:0]
+	for _, txn := range txns {
+		if !txn.Start.Before(since) {
+			filtered = append(filtered, txn)
+		}
+	}
+	return filtered
+}
+
+type recordedTxn struct {
+	Start       time.Time
+	Stop        time.Time
+	SeqNum      uint64
+	TxnType     enumValue
+	ResyncType  enumValue
+	Description string
+	Values      []json.RawMessage
+	Executed    []recordedTxnOp
+}
+
+type recordedTxnOp struct {
+	Operation enumValue
+	Key       string
+	NewState  enumValue
+	NewErrMsg string
+	IsDerived bool
+	IsRetry   bool
+}
+
+// enumValue is an enum encoded either as number or as string.
+type enumValue string
+
+func (e *enumValue) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*e = enumValue(s)
+		return nil
+	}
+	var n int64
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*e = enumValue(strconv.FormatInt(n, 10))
+	return nil
+}
+
+var (
+	txnTypes    = []string{"SB", "NB", "retry"}
+	resyncTypes = []string{"", "full", "upstream", "downstream"}
+	txnOps      = []string{"undefined", "validate", "create", "update", "delete"}
+	valueStates = []string{
+		"NONEXISTENT", "MISSING", "UNIMPLEMENTED", "REMOVED", "CONFIGURED",
+		"OBTAINED", "DISCOVERED", "PENDING", "INVALID", "FAILED", "RETRYING",
+	}
+)
+
+// enumName returns name of numeric enum value or the value itself.
+func enumName(v enumValue, names []string) string {
+	n, err := strconv.Atoi(string(v))
+	if err != nil {
+		return string(v)
+	}
+	if n >= 0 && n < len(names) {
+		return names[n]
+	}
+	return string(v)
+}
+
+func txnTypeName(v enumValue) string {
+	switch s := strings.ToLower(string(v)); {
+	case strings.Contains(s, "nb"):
+		return "NB"
+	case strings.Contains(s, "sb"):
+		return "SB"
+	case strings.Contains(s, "retry"):
+		return "retry"
+	}
+	return enumName(v, txnTypes)
+}
+
+func parseTxnHistory(data []byte) ([]Txn, error) {
+	var records []recordedTxn
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("unmarshaling transaction history failed: %w", err)
+	}
+	txns := make([]Txn, 0, len(records))
+	for _, r := range records {
+		txn := Txn{
+			SeqNum:      r.SeqNum,
+			Type:        txnTypeName(r.TxnType),
+			Resync:      strings.ToLower(strings.TrimSuffix(enumName(r.ResyncType, resyncTypes), "Resync")),
+			Description: r.Description,
+			Start:       r.Start,
+			Stop:        r.Stop,
+			Values:      len(r.Values),
+		}
+		if txn.Resync == "not" {
+			txn.Resync = ""
+		}
+		for _, op := range r.Executed {
+			o := TxnOp{
+				Operation: strings.ToLower(enumName(op.Operation, txnOps)),
+				Key:       op.Key,
+				NewState:  enumName(op.NewState, valueStates),
+				Error:     op.NewErrMsg,
+				IsDerived: op.IsDerived,
+				IsRetry:   op.IsRetry,
+			}
+			txn.Ops = append(txn.Ops, o)
+			if o.Error != "" {
+				txn.Errors = append(txn.Errors, fmt.Sprintf("%s: %s", o.Key, o.Error))
+			}
+		}
+		txns = append(txns, txn)
+	}
+	return txns, nil
+}
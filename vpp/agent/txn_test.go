@@ -0,0 +1,53 @@
+package agent
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTxnHistory(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantType   string
+		wantResync string
+		wantOps    []TxnOp
+		wantErrs   []string
+	}{
+		{
+			name:       "numeric enums",
+			data:       `[{"SeqNum":3,"TxnType":1,"ResyncType":1,"Values":[{},{}],"Executed":[{"Operation":2,"Key":"config/vpp/v2/interfaces/tap1","NewState":4}]}]`,
+			wantType:   "NB",
+			wantResync: "full",
+			wantOps:    []TxnOp{{Operation: "create", Key: "config/vpp/v2/interfaces/tap1", NewState: "CONFIGURED"}},
+		},
+		{
+			name:     "string enums with error",
+			data:     `[{"SeqNum":4,"TxnType":"RETRY_FAILED_OPS","ResyncType":"NotResync","Executed":[{"Operation":"UPDATE","Key":"k","NewState":"RETRYING","NewErrMsg":"boom","IsRetry":true}]}]`,
+			wantType: "retry",
+			wantOps:  []TxnOp{{Operation: "update", Key: "k", NewState: "RETRYING", Error: "boom", IsRetry: true}},
+			wantErrs: []string{"k: boom"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txns, err := parseTxnHistory([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("parseTxnHistory() error = %v", err)
+			}
+			if len(txns) != 1 {
+				t.Fatalf("expected 1 txn, got %d", len(txns))
+			}
+			txn := txns[0]
+			if txn.Type != tt.wantType || txn.Resync != tt.wantResync {
+				t.Errorf("got type %q resync %q, want %q %q", txn.Type, txn.Resync, tt.wantType, tt.wantResync)
+			}
+			if !reflect.DeepEqual(txn.Ops, tt.wantOps) {
+				t.Errorf("got ops %+v, want %+v", txn.Ops, tt.wantOps)
+			}
+			if !reflect.DeepEqual(txn.Errors, tt.wantErrs) {
+				t.Errorf("got errors %v, want %v", txn.Errors, tt.wantErrs)
+			}
+		})
+	}
+}
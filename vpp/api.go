@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	govppapi "go.fd.io/govpp/api"
 
 	"go.ligato.io/vpp-probe/vpp/api"
 	"go.ligato.io/vpp-probe/vpp/binapi"
@@ -105,6 +106,15 @@ func (v *Instance) ListInterfaces() ([]*api.Interface, error) {
 	return nil, ErrAPIUnavailable
 }
 
+// SendRequest sends binary API request message with name filled from JSON data
+// and returns received replies.
+func (v *Instance) SendRequest(name string, data []byte) ([]govppapi.Message, error) {
+	if v.api == nil {
+		return nil, ErrAPIUnavailable
+	}
+	return binapi.SendRequestChan(v.api, v.binapiMsgs, name, data)
+}
+
 func (v *Instance) GetUptime() (time.Duration, error) {
 	// uptime not available via binary API
 
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"time"
 )
 
@@ -33,6 +34,7 @@ type VPP interface {
 	// --------------
 
 	ListInterfaces() ([]*Interface, error)
+	WatchInterfaceEvents(ctx context.Context, interval time.Duration) (<-chan InterfaceEvent, error)
 
 	// --------------
 	// Stats
@@ -40,6 +42,7 @@ type VPP interface {
 
 	ListStats() ([]string, error)
 	DumpStats() (*VppStats, error)
+	GetStats(patterns ...string) ([]StatEntry, error)
 	// GetCounter(counter string) (string, error)
 	// GetInterfaceStats(iface string) (string, error)
 }
@@ -19,6 +19,60 @@ type (
 		MTUs MTU
 		IPs  []string
 		VRF  VRF
+
+		Link     Link
+		RxQueues int           `json:",omitempty"`
+		TxQueues int           `json:",omitempty"`
+		Sub      *SubInterface `json:",omitempty"`
+		Bond     *Bond         `json:",omitempty"`
+		BondedTo *uint32       `json:",omitempty"`
+		Tunnel   *Tunnel       `json:",omitempty"`
+		Features []FeatureArc  `json:",omitempty"`
+	}
+
+	// Link contains link details of hardware interface.
+	Link struct {
+		Speed  uint64 `json:",omitempty"` // in kbps
+		Duplex string `json:",omitempty"`
+	}
+
+	// SubInterface contains details of sub-interface.
+	SubInterface struct {
+		Parent    uint32
+		SubID     uint32
+		NumTags   uint8  `json:",omitempty"`
+		OuterVlan uint16 `json:",omitempty"`
+		InnerVlan uint16 `json:",omitempty"`
+	}
+
+	// Bond contains details of bond interface.
+	Bond struct {
+		ID            uint32
+		Mode          string
+		LoadBalance   string `json:",omitempty"`
+		ActiveMembers uint32
+		Members       []BondMember `json:",omitempty"`
+	}
+
+	BondMember struct {
+		Index   uint32
+		Name    string
+		Passive bool   `json:",omitempty"`
+		Weight  uint32 `json:",omitempty"`
+	}
+
+	// Tunnel contains endpoints of tunnel interface.
+	Tunnel struct {
+		Type string
+		Src  string
+		Dst  string
+		VNI  uint32 `json:",omitempty"`
+	}
+
+	// FeatureArc is a feature arc with features enabled on interface.
+	FeatureArc struct {
+		Arc      string
+		Features []string
 	}
 
 	Status struct {
@@ -0,0 +1,14 @@
+package api
+
+import (
+	"time"
+)
+
+// InterfaceEvent is a change of interface status reported by VPP.
+type InterfaceEvent struct {
+	Time    time.Time
+	Index   uint32
+	Name    string `json:",omitempty"`
+	Status  Status
+	Deleted bool `json:",omitempty"`
+}
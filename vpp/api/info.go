@@ -42,6 +42,15 @@ func (uptime Uptime) MarshalText() (text []byte, err error) {
 	return []byte(uptime.String()), nil
 }
 
+func (uptime *Uptime) UnmarshalText(text []byte) error {
+	d, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*uptime = Uptime(d / time.Second)
+	return nil
+}
+
 func (uptime Uptime) String() string {
 	d := time.Duration(uptime) * time.Second
 	return d.String()
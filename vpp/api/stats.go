@@ -12,6 +12,13 @@ type (
 		Nodes      []govppapi.NodeCounters `json:",omitempty"`
 		Interfaces map[string]InterfaceStats
 		Counters   map[string]uint64
+
+		// CountersPerWorker contains per-thread values of non-zero error
+		// counters, index 0 being the main thread.
+		CountersPerWorker map[string][]uint64 `json:",omitempty"`
+		// NodesPerWorker contains per-thread counters of nodes with
+		// calls, available only with access to stats directory.
+		NodesPerWorker map[string][]govppapi.NodeCounters `json:",omitempty"`
 	}
 
 	IfaceCounter struct {
@@ -62,3 +69,38 @@ type (
 func (counter InterfaceCounter) MarshalText() (text []byte, err error) {
 	return []byte(counter.String()), nil
 }*/
+
+// StatType is a type of stats segment entry.
+type StatType string
+
+const (
+	StatScalar   StatType = "scalar"
+	StatError    StatType = "error"
+	StatCounter  StatType = "counter"
+	StatCombined StatType = "combined"
+	StatName     StatType = "name"
+	StatEmpty    StatType = "empty"
+)
+
+type (
+	// StatEntry is a single entry of stats segment directory. Values
+	// of counters are indexed by worker and then by object index.
+	StatEntry struct {
+		Name     string
+		Type     StatType
+		Symlink  bool                `json:",omitempty"`
+		Scalar   *float64            `json:",omitempty"`
+		Errors   []uint64            `json:",omitempty"`
+		Counters [][]uint64          `json:",omitempty"`
+		Combined [][]CombinedCounter `json:",omitempty"`
+		Names    []string            `json:",omitempty"`
+		// Aggregated is set if values of all workers are summed into
+		// a single worker, e.g. when stats directory is not available.
+		Aggregated bool `json:",omitempty"`
+	}
+
+	CombinedCounter struct {
+		Packets uint64
+		Bytes   uint64
+	}
+)
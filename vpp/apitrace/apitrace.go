@@ -0,0 +1,99 @@
+// Package apitrace handles capturing and decoding of VPP binary API traces.
+package apitrace
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	govppapi "go.fd.io/govpp/api"
+)
+
+// TraceDir is the directory where VPP saves API trace files.
+const TraceDir = "/tmp"
+
+// CLI is an interface for accessing VPP CLI.
+type CLI interface {
+	RunCli(cmd string) (string, error)
+}
+
+// Trace is a decoded API trace.
+type Trace struct {
+	Wrapped  bool
+	Messages []*Message
+}
+
+// Message is a single traced API message.
+type Message struct {
+	Index   int
+	ID      uint16
+	Name    string
+	Type    string           `json:",omitempty"`
+	Context uint32           `json:",omitempty"`
+	Data    govppapi.Message `json:",omitempty"`
+	Error   string           `json:",omitempty"`
+}
+
+// Call is a request paired with its replies.
+type Call struct {
+	Request *Message
+	Replies []*Message `json:",omitempty"`
+}
+
+// Start clears any previous API trace and starts tracing API messages.
+func Start(cli CLI) error {
+	if out, err := cli.RunCli("api trace free"); err != nil {
+		return fmt.Errorf("api trace free failed: %w\n%s", err, out)
+	}
+	if out, err := cli.RunCli("api trace on"); err != nil {
+		return fmt.Errorf("api trace on failed: %w\n%s", err, out)
+	}
+	// older VPP versions do not support tracing of sent messages
+	_, _ = cli.RunCli("api trace tx on")
+	return nil
+}
+
+// Save saves the API trace to file with name and stops tracing. It returns
+// list of trace files saved on the instance.
+func Save(cli CLI, name string) ([]string, error) {
+	var files []string
+	out, err := cli.RunCli("api trace save " + name)
+	if err != nil {
+		return nil, fmt.Errorf("api trace save failed: %w\n%s", err, out)
+	} else if strings.Contains(out, "error") || strings.Contains(out, "failed") {
+		return nil, fmt.Errorf("api trace save failed: %s", strings.TrimSpace(out))
+	}
+	files = append(files, path.Join(TraceDir, name))
+
+	txName := name + "-tx"
+	if out, err := cli.RunCli("api trace tx save " + txName); err == nil &&
+		!strings.Contains(out, "error") && !strings.Contains(out, "unknown") {
+		files = append(files, path.Join(TraceDir, txName))
+	}
+
+	if out, err := cli.RunCli("api trace off"); err != nil {
+		return files, fmt.Errorf("api trace off failed: %w\n%s", err, out)
+	}
+	return files, nil
+}
+
+// PairCalls pairs requests with replies using message context. Messages
+// without matching request (e.g. events) are returned as calls without replies.
+func PairCalls(messages []*Message) []*Call {
+	var calls []*Call
+	pending := map[uint32]*Call{}
+	for _, msg := range messages {
+		if msg.Type == "reply" {
+			if call, ok := pending[msg.Context]; ok {
+				call.Replies = append(call.Replies, msg)
+				continue
+			}
+		}
+		call := &Call{Request: msg}
+		if msg.Type == "request" {
+			pending[msg.Context] = call
+		}
+		calls = append(calls, call)
+	}
+	return calls
+}
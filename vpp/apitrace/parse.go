@@ -0,0 +1,200 @@
+package apitrace
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+
+	govppapi "go.fd.io/govpp/api"
+	"go.fd.io/govpp/codec"
+)
+
+// Trace file layout as written by 'api trace save' (vl_msg_api_trace_save):
+//
+//	u8  endian
+//	u8  wrapped
+//	u32 nitems        (network byte order)
+//	u32 msgtbl_size   (network byte order)
+//	u8  msgtbl[msgtbl_size]
+//	nitems * {
+//	  u32 msg_length  (network byte order)
+//	  u8  msg[msg_length]
+//	}
+//
+// The message table is serialized using vppinfra serialize functions:
+//
+//	u32 nmsg (network byte order)
+//	nmsg * { likely-small-uint index, cstring name_crc }
+
+const traceHeaderSize = 10
+
+// ParseTrace parses API trace file data and decodes the traced messages
+// using the list of known messages.
+func ParseTrace(data []byte, known []govppapi.Message) (*Trace, error) {
+	if len(data) < traceHeaderSize {
+		return nil, fmt.Errorf("trace data too short (%d bytes)", len(data))
+	}
+
+	wrapped := data[1] != 0
+	nitems := binary.BigEndian.Uint32(data[2:6])
+	msgtblSize := binary.BigEndian.Uint32(data[6:10])
+
+	data = data[traceHeaderSize:]
+	if uint32(len(data)) < msgtblSize {
+		return nil, fmt.Errorf("message table truncated (%d < %d bytes)", len(data), msgtblSize)
+	}
+	msgTable, err := parseMessageTable(data[:msgtblSize])
+	if err != nil {
+		return nil, fmt.Errorf("parsing message table failed: %w", err)
+	}
+	data = data[msgtblSize:]
+
+	knownMsgs := make(map[string]govppapi.Message, len(known))
+	for _, msg := range known {
+		knownMsgs[msg.GetMessageName()+"_"+msg.GetCrcString()] = msg
+	}
+
+	trace := &Trace{
+		Wrapped: wrapped,
+	}
+	for i := uint32(0); i < nitems; i++ {
+		if len(data) < 4 {
+			return trace, fmt.Errorf("message #%d: length truncated", i)
+		}
+		msgLen := binary.BigEndian.Uint32(data[:4])
+		data = data[4:]
+		if uint32(len(data)) < msgLen {
+			return trace, fmt.Errorf("message #%d: data truncated (%d < %d bytes)", i, len(data), msgLen)
+		}
+		msg := decodeMessage(int(i), data[:msgLen], msgTable, knownMsgs)
+		trace.Messages = append(trace.Messages, msg)
+		data = data[msgLen:]
+	}
+
+	return trace, nil
+}
+
+func decodeMessage(index int, raw []byte, msgTable map[uint16]string, knownMsgs map[string]govppapi.Message) *Message {
+	msg := &Message{
+		Index: index,
+	}
+	if len(raw) < 2 {
+		msg.Error = "message too short"
+		return msg
+	}
+	msg.ID = binary.BigEndian.Uint16(raw[:2])
+
+	nameCrc, ok := msgTable[msg.ID]
+	if !ok {
+		msg.Error = fmt.Sprintf("unknown message ID %d", msg.ID)
+		return msg
+	}
+	msg.Name = nameCrc
+
+	known, ok := knownMsgs[nameCrc]
+	if !ok {
+		msg.Error = "message not found in binapi"
+		return msg
+	}
+	msg.Name = known.GetMessageName()
+	msg.Type = messageTypeString(known.GetMessageType())
+
+	msg.Context = decodeContext(raw, known.GetMessageType())
+
+	data := reflect.New(reflect.TypeOf(known).Elem()).Interface().(govppapi.Message)
+	if err := codec.DefaultCodec.DecodeMsg(raw, data); err != nil {
+		msg.Error = fmt.Sprintf("decoding failed: %v", err)
+		return msg
+	}
+	msg.Data = data
+
+	return msg
+}
+
+// decodeContext returns context field from the message header.
+func decodeContext(raw []byte, typ govppapi.MessageType) uint32 {
+	switch typ {
+	case govppapi.RequestMessage:
+		// msg_id (u16), client_index (u32), context (u32)
+		if len(raw) >= 10 {
+			return binary.BigEndian.Uint32(raw[6:10])
+		}
+	case govppapi.ReplyMessage:
+		// msg_id (u16), context (u32)
+		if len(raw) >= 6 {
+			return binary.BigEndian.Uint32(raw[2:6])
+		}
+	}
+	return 0
+}
+
+func messageTypeString(typ govppapi.MessageType) string {
+	switch typ {
+	case govppapi.RequestMessage:
+		return "request"
+	case govppapi.ReplyMessage:
+		return "reply"
+	case govppapi.EventMessage:
+		return "event"
+	default:
+		return "other"
+	}
+}
+
+func parseMessageTable(data []byte) (map[uint16]string, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("message table too short")
+	}
+	nmsg := binary.BigEndian.Uint32(data[:4])
+	pos := 4
+
+	table := make(map[uint16]string, nmsg)
+	for i := uint32(0); i < nmsg; i++ {
+		idx, n, err := unserializeLikelySmallUint(data[pos:])
+		if err != nil {
+			return nil, fmt.Errorf("entry #%d index: %w", i, err)
+		}
+		pos += n
+		strLen, n, err := unserializeLikelySmallUint(data[pos:])
+		if err != nil {
+			return nil, fmt.Errorf("entry #%d name length: %w", i, err)
+		}
+		pos += n
+		if uint64(len(data)-pos) < strLen {
+			return nil, fmt.Errorf("entry #%d name truncated", i)
+		}
+		table[uint16(idx)] = string(data[pos : pos+int(strLen)])
+		pos += int(strLen)
+	}
+
+	return table, nil
+}
+
+// unserializeLikelySmallUint decodes integer encoded with vppinfra
+// serialize_likely_small_unsigned_integer and returns number of bytes used.
+func unserializeLikelySmallUint(b []byte) (uint64, int, error) {
+	if len(b) < 1 {
+		return 0, 0, fmt.Errorf("unexpected end of data")
+	}
+	switch {
+	case b[0]&1 == 1:
+		return uint64(b[0] >> 1), 1, nil
+	case b[0]&3 == 2:
+		if len(b) < 2 {
+			return 0, 0, fmt.Errorf("unexpected end of data")
+		}
+		v := binary.LittleEndian.Uint16(b[:2])
+		return uint64(v>>2) + 1<<7, 2, nil
+	case b[0]&7 == 4:
+		if len(b) < 4 {
+			return 0, 0, fmt.Errorf("unexpected end of data")
+		}
+		v := binary.LittleEndian.Uint32(b[:4])
+		return uint64(v>>3) + 1<<7 + 1<<14, 4, nil
+	default:
+		if len(b) < 9 {
+			return 0, 0, fmt.Errorf("unexpected end of data")
+		}
+		return binary.LittleEndian.Uint64(b[1:9]) + 1<<7 + 1<<14 + 1<<29, 9, nil
+	}
+}
@@ -0,0 +1,116 @@
+package apitrace
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	govppapi "go.fd.io/govpp/api"
+	"go.fd.io/govpp/binapi/memclnt"
+	"go.fd.io/govpp/codec"
+)
+
+func encodeTrace(t *testing.T, msgTable map[uint16]govppapi.Message, msgs []uint16, context uint32) []byte {
+	t.Helper()
+
+	var tbl bytes.Buffer
+	binary.Write(&tbl, binary.BigEndian, uint32(len(msgTable)))
+	for id, msg := range msgTable {
+		name := msg.GetMessageName() + "_" + msg.GetCrcString()
+		tbl.WriteByte(byte(id)<<1 | 1)
+		tbl.WriteByte(byte(len(name))<<1 | 1)
+		tbl.WriteString(name)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(0)
+	buf.WriteByte(0)
+	binary.Write(&buf, binary.BigEndian, uint32(len(msgs)))
+	binary.Write(&buf, binary.BigEndian, uint32(tbl.Len()))
+	buf.Write(tbl.Bytes())
+	for _, id := range msgs {
+		msg, ok := msgTable[id]
+		if !ok {
+			binary.Write(&buf, binary.BigEndian, uint32(2))
+			binary.Write(&buf, binary.BigEndian, id)
+			continue
+		}
+		data, err := codec.DefaultCodec.EncodeMsg(msg, id)
+		if err != nil {
+			t.Fatalf("encoding message failed: %v", err)
+		}
+		if msg.GetMessageType() == govppapi.RequestMessage {
+			binary.BigEndian.PutUint32(data[6:10], context)
+		} else {
+			binary.BigEndian.PutUint32(data[2:6], context)
+		}
+		binary.Write(&buf, binary.BigEndian, uint32(len(data)))
+		buf.Write(data)
+	}
+	return buf.Bytes()
+}
+
+func TestParseTrace(t *testing.T) {
+	msgTable := map[uint16]govppapi.Message{
+		10: &memclnt.ControlPing{},
+		11: &memclnt.ControlPingReply{VpePID: 123},
+	}
+	data := encodeTrace(t, msgTable, []uint16{10, 11, 12}, 7)
+
+	trace, err := ParseTrace(data, memclnt.AllMessages())
+	if err != nil {
+		t.Fatalf("ParseTrace() error = %v", err)
+	}
+	if len(trace.Messages) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(trace.Messages))
+	}
+
+	ping := trace.Messages[0]
+	if ping.Name != "control_ping" || ping.Type != "request" || ping.Context != 7 || ping.Error != "" {
+		t.Errorf("unexpected request: %+v", ping)
+	}
+	reply := trace.Messages[1]
+	if reply.Name != "control_ping_reply" || reply.Type != "reply" || reply.Context != 7 {
+		t.Errorf("unexpected reply: %+v", reply)
+	}
+	if r, ok := reply.Data.(*memclnt.ControlPingReply); !ok || r.VpePID != 123 {
+		t.Errorf("unexpected reply data: %#v", reply.Data)
+	}
+	if unknown := trace.Messages[2]; unknown.Error == "" {
+		t.Errorf("expected error for unknown message: %+v", unknown)
+	}
+
+	calls := PairCalls(trace.Messages)
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 calls, got %d", len(calls))
+	}
+	if calls[0].Request != ping || len(calls[0].Replies) != 1 || calls[0].Replies[0] != reply {
+		t.Errorf("unexpected call: %+v", calls[0])
+	}
+}
+
+func TestUnserializeLikelySmallUint(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want uint64
+		n    int
+	}{
+		{"1 byte", []byte{0x0b}, 5, 1},
+		{"2 bytes", []byte{0x06, 0x00}, 1<<7 + 1, 2},
+		{"4 bytes", []byte{0x0c, 0x00, 0x00, 0x00}, 1<<7 + 1<<14 + 1, 4},
+		{"9 bytes", []byte{0x00, 0x10, 0, 0, 0, 0, 0, 0, 0}, 1<<7 + 1<<14 + 1<<29 + 16, 9},
+		{"9 bytes min", []byte{0x00, 0, 0, 0, 0, 0, 0, 0, 0}, 1<<7 + 1<<14 + 1<<29, 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, n, err := unserializeLikelySmallUint(tt.data)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want || n != tt.n {
+				t.Errorf("got %d (%d bytes), want %d (%d bytes)", got, n, tt.want, tt.n)
+			}
+		})
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io"
 	"math"
-	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -272,6 +271,7 @@ func ListInterfacesChan(ch govppapi.Channel) ([]*api.Interface, error) {
 		iface.IPs = IPs
 		iface.VRF = *VRFs
 	}
+	addInterfaceDetailsChan(ch, list)
 	return list, nil
 }
 
@@ -290,16 +290,7 @@ func dumpInterfaces(conn govppapi.Connection) ([]*api.Interface, error) {
 		} else if err != nil {
 			return nil, fmt.Errorf("DumpSwInterface failed: %v", err)
 		}
-		ifaces = append(ifaces, &api.Interface{
-			Index:      uint32(iface.SwIfIndex),
-			Name:       strings.Trim(iface.InterfaceName, "\x00"),
-			Tag:        strings.Trim(iface.Tag, "\x00"),
-			Type:       vppIfTypeToString(iface.Type),
-			DeviceType: iface.InterfaceDevType,
-			Status:     vppIfStatusFlagsToStatus(iface.Flags),
-			MTUs:       vppInterfaceMTU(iface.Mtu, iface.LinkMtu),
-			MAC:        iface.L2Address.String(),
-		})
+		ifaces = append(ifaces, vppInterfaceDetails(iface))
 	}
 	return ifaces, nil
 }
@@ -316,16 +307,7 @@ func dumpInterfacesChan(ch govppapi.Channel) ([]*api.Interface, error) {
 		} else if err != nil {
 			return nil, fmt.Errorf("DumpSwInterface failed: %v", err)
 		}
-		ifaces = append(ifaces, &api.Interface{
-			Index:      uint32(iface.SwIfIndex),
-			Name:       strings.Trim(iface.InterfaceName, "\x00"),
-			Tag:        strings.Trim(iface.Tag, "\x00"),
-			Type:       vppIfTypeToString(iface.Type),
-			DeviceType: iface.InterfaceDevType,
-			Status:     vppIfStatusFlagsToStatus(iface.Flags),
-			MTUs:       vppInterfaceMTU(iface.Mtu, iface.LinkMtu),
-			MAC:        iface.L2Address.String(),
-		})
+		ifaces = append(ifaces, vppInterfaceDetails(iface))
 	}
 	return ifaces, nil
 }
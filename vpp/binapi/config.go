@@ -0,0 +1,420 @@
+package binapi
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	govppapi "go.fd.io/govpp/api"
+
+	"go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/af_packet"
+	"go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/fib_types"
+	"go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/interface_types"
+	"go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/ip"
+	"go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/ipsec"
+	"go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/ipsec_types"
+	"go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/l2"
+	"go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/memif"
+	"go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/tapv2"
+	"go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/vhost_user"
+	"go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/wireguard"
+	vpp_interfaces "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/interfaces"
+	vpp_ipsec "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/ipsec"
+	vpp_l2 "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/l2"
+	vpp_l3 "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/l3"
+	vpp_wg "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/wireguard"
+)
+
+// IPSecConfig contains IPSec configuration dumped from VPP.
+type IPSecConfig struct {
+	SAs         []*vpp_ipsec.SecurityAssociation
+	SPDs        []*vpp_ipsec.SecurityPolicyDatabase
+	SPs         []*vpp_ipsec.SecurityPolicy
+	TunProtects []*vpp_ipsec.TunnelProtection
+}
+
+// DumpMemifsChan returns memif links by interface index.
+func DumpMemifsChan(ch govppapi.Channel) (map[uint32]*vpp_interfaces.MemifLink, error) {
+	sockets := map[uint32]string{}
+	stream := ch.SendMultiRequest(&memif.MemifSocketFilenameDump{})
+	for {
+		details := &memif.MemifSocketFilenameDetails{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("MemifSocketFilenameDump failed: %w", err)
+		}
+		sockets[details.SocketID] = details.SocketFilename
+	}
+
+	links := map[uint32]*vpp_interfaces.MemifLink{}
+	stream = ch.SendMultiRequest(&memif.MemifDump{})
+	for {
+		details := &memif.MemifDetails{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("MemifDump failed: %w", err)
+		}
+		links[uint32(details.SwIfIndex)] = &vpp_interfaces.MemifLink{
+			Master:         details.Role == memif.MEMIF_ROLE_API_MASTER,
+			Mode:           vpp_interfaces.MemifLink_MemifMode(details.Mode),
+			Id:             details.ID,
+			SocketFilename: sockets[details.SockID],
+			RingSize:       details.RingSize,
+			BufferSize:     uint32(details.BufferSize),
+		}
+	}
+	return links, nil
+}
+
+// DumpTapsChan returns tap links by interface index.
+func DumpTapsChan(ch govppapi.Channel) (map[uint32]*vpp_interfaces.TapLink, error) {
+	links := map[uint32]*vpp_interfaces.TapLink{}
+	stream := ch.SendMultiRequest(&tapv2.SwInterfaceTapV2Dump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	for {
+		details := &tapv2.SwInterfaceTapV2Details{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("SwInterfaceTapV2Dump failed: %w", err)
+		}
+		links[uint32(details.SwIfIndex)] = &vpp_interfaces.TapLink{
+			Version:        2,
+			HostIfName:     strings.Trim(details.HostIfName, "\x00"),
+			ToMicroservice: strings.Trim(details.HostNamespace, "\x00"),
+			RxRingSize:     uint32(details.RxRingSz),
+			TxRingSize:     uint32(details.TxRingSz),
+		}
+	}
+	return links, nil
+}
+
+// DumpAfPacketsChan returns af_packet links by interface index.
+func DumpAfPacketsChan(ch govppapi.Channel) (map[uint32]*vpp_interfaces.AfpacketLink, error) {
+	links := map[uint32]*vpp_interfaces.AfpacketLink{}
+	stream := ch.SendMultiRequest(&af_packet.AfPacketDump{})
+	for {
+		details := &af_packet.AfPacketDetails{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("AfPacketDump failed: %w", err)
+		}
+		links[uint32(details.SwIfIndex)] = &vpp_interfaces.AfpacketLink{
+			HostIfName: strings.Trim(details.HostIfName, "\x00"),
+		}
+	}
+	return links, nil
+}
+
+// DumpVhostUsersChan returns socket filenames of vhost-user interfaces
+// by interface index.
+func DumpVhostUsersChan(ch govppapi.Channel) (map[uint32]string, error) {
+	sockets := map[uint32]string{}
+	stream := ch.SendMultiRequest(&vhost_user.SwInterfaceVhostUserDump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	for {
+		details := &vhost_user.SwInterfaceVhostUserDetails{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("SwInterfaceVhostUserDump failed: %w", err)
+		}
+		sockets[uint32(details.SwIfIndex)] = strings.Trim(details.SockFilename, "\x00")
+	}
+	return sockets, nil
+}
+
+// WireguardConfig contains wireguard interfaces and peers dumped from VPP.
+type WireguardConfig struct {
+	// Links are wireguard links by interface index.
+	Links map[uint32]*vpp_interfaces.WireguardLink
+	// PublicKeys are base64 encoded public keys by interface index.
+	PublicKeys map[uint32]string
+	Peers      []*vpp_wg.Peer
+}
+
+// DumpWireguardChan returns wireguard interfaces and peers. Private keys
+// are not dumped.
+func DumpWireguardChan(ch govppapi.Channel, ifNames map[uint32]string) (*WireguardConfig, error) {
+	config := &WireguardConfig{
+		Links:      map[uint32]*vpp_interfaces.WireguardLink{},
+		PublicKeys: map[uint32]string{},
+	}
+
+	stream := ch.SendMultiRequest(&wireguard.WireguardInterfaceDump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	for {
+		details := &wireguard.WireguardInterfaceDetails{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("WireguardInterfaceDump failed: %w", err)
+		}
+		wg := details.Interface
+		config.Links[uint32(wg.SwIfIndex)] = &vpp_interfaces.WireguardLink{
+			Port:    uint32(wg.Port),
+			SrcAddr: wg.SrcIP.String(),
+		}
+		config.PublicKeys[uint32(wg.SwIfIndex)] = base64.StdEncoding.EncodeToString(wg.PublicKey)
+	}
+
+	stream = ch.SendMultiRequest(&wireguard.WireguardPeersDump{PeerIndex: ^uint32(0)})
+	for {
+		details := &wireguard.WireguardPeersDetails{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("WireguardPeersDump failed: %w", err)
+		}
+		peer := details.Peer
+		var allowedIPs []string
+		for _, prefix := range peer.AllowedIps {
+			allowedIPs = append(allowedIPs, prefix.String())
+		}
+		config.Peers = append(config.Peers, &vpp_wg.Peer{
+			PublicKey:           base64.StdEncoding.EncodeToString(peer.PublicKey),
+			Port:                uint32(peer.Port),
+			PersistentKeepalive: uint32(peer.PersistentKeepalive),
+			Endpoint:            peer.Endpoint.String(),
+			WgIfName:            ifNames[uint32(peer.SwIfIndex)],
+			Flags:               uint32(peer.Flags),
+			AllowedIps:          allowedIPs,
+		})
+	}
+
+	return config, nil
+}
+
+// DumpL2XconnectsChan returns L2 cross-connects with interface names
+// resolved using ifNames.
+func DumpL2XconnectsChan(ch govppapi.Channel, ifNames map[uint32]string) ([]*vpp_l2.XConnectPair, error) {
+	var list []*vpp_l2.XConnectPair
+	stream := ch.SendMultiRequest(&l2.L2XconnectDump{})
+	for {
+		details := &l2.L2XconnectDetails{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("L2XconnectDump failed: %w", err)
+		}
+		list = append(list, &vpp_l2.XConnectPair{
+			ReceiveInterface:  ifNames[uint32(details.RxSwIfIndex)],
+			TransmitInterface: ifNames[uint32(details.TxSwIfIndex)],
+		})
+	}
+	return list, nil
+}
+
+// DumpRoutesChan returns routes with next hop address from all FIB tables.
+// Routes without next hop (connected, local, drop) are skipped.
+func DumpRoutesChan(ch govppapi.Channel, ifNames map[uint32]string) ([]*vpp_l3.Route, error) {
+	var tables []ip.IPTable
+	stream := ch.SendMultiRequest(&ip.IPTableDump{})
+	for {
+		details := &ip.IPTableDetails{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("IPTableDump failed: %w", err)
+		}
+		tables = append(tables, details.Table)
+	}
+
+	var list []*vpp_l3.Route
+	for _, table := range tables {
+		stream := ch.SendMultiRequest(&ip.IPRouteDump{Table: table})
+		for {
+			details := &ip.IPRouteDetails{}
+			last, err := stream.ReceiveReply(details)
+			if last {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("IPRouteDump failed: %w", err)
+			}
+			for _, path := range details.Route.Paths {
+				nextHop := fibPathNextHop(path)
+				if nextHop == "" {
+					continue
+				}
+				list = append(list, &vpp_l3.Route{
+					Type:              vpp_l3.Route_INTRA_VRF,
+					VrfId:             details.Route.TableID,
+					DstNetwork:        details.Route.Prefix.String(),
+					NextHopAddr:       nextHop,
+					OutgoingInterface: ifNames[path.SwIfIndex],
+					Weight:            uint32(path.Weight),
+					Preference:        uint32(path.Preference),
+				})
+			}
+		}
+	}
+	return list, nil
+}
+
+func fibPathNextHop(path fib_types.FibPath) string {
+	var nh string
+	switch path.Proto {
+	case fib_types.FIB_API_PATH_NH_PROTO_IP4:
+		nh = path.Nh.Address.GetIP4().String()
+	case fib_types.FIB_API_PATH_NH_PROTO_IP6:
+		nh = path.Nh.Address.GetIP6().String()
+	}
+	if nh == "0.0.0.0" || nh == "::" {
+		return ""
+	}
+	return nh
+}
+
+// DumpIPSecChan returns IPSec SAs, SPDs with policies and tunnel protections.
+func DumpIPSecChan(ch govppapi.Channel, ifNames map[uint32]string) (*IPSecConfig, error) {
+	config := &IPSecConfig{}
+
+	stream := ch.SendMultiRequest(&ipsec.IpsecSaV3Dump{SaID: ^uint32(0)})
+	for {
+		details := &ipsec.IpsecSaV3Details{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("IpsecSaV3Dump failed: %w", err)
+		}
+		config.SAs = append(config.SAs, ipsecSA(details.Entry))
+	}
+
+	var spds []uint32
+	stream = ch.SendMultiRequest(&ipsec.IpsecSpdsDump{})
+	for {
+		details := &ipsec.IpsecSpdsDetails{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("IpsecSpdsDump failed: %w", err)
+		}
+		spds = append(spds, details.SpdID)
+	}
+
+	for _, spdID := range spds {
+		spd := &vpp_ipsec.SecurityPolicyDatabase{Index: spdID}
+		stream := ch.SendMultiRequest(&ipsec.IpsecSpdInterfaceDump{
+			SpdIndex:      spdID,
+			SpdIndexValid: 1,
+		})
+		for {
+			details := &ipsec.IpsecSpdInterfaceDetails{}
+			last, err := stream.ReceiveReply(details)
+			if last {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("IpsecSpdInterfaceDump failed: %w", err)
+			}
+			spd.Interfaces = append(spd.Interfaces, &vpp_ipsec.SecurityPolicyDatabase_Interface{
+				Name: ifNames[uint32(details.SwIfIndex)],
+			})
+		}
+		config.SPDs = append(config.SPDs, spd)
+
+		stream = ch.SendMultiRequest(&ipsec.IpsecSpdDump{
+			SpdID: spdID,
+			SaID:  ^uint32(0),
+		})
+		for {
+			details := &ipsec.IpsecSpdDetails{}
+			last, err := stream.ReceiveReply(details)
+			if last {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("IpsecSpdDump failed: %w", err)
+			}
+			e := details.Entry
+			config.SPs = append(config.SPs, &vpp_ipsec.SecurityPolicy{
+				SpdIndex:        e.SpdID,
+				SaIndex:         e.SaID,
+				Priority:        e.Priority,
+				IsOutbound:      e.IsOutbound,
+				RemoteAddrStart: e.RemoteAddressStart.String(),
+				RemoteAddrStop:  e.RemoteAddressStop.String(),
+				LocalAddrStart:  e.LocalAddressStart.String(),
+				LocalAddrStop:   e.LocalAddressStop.String(),
+				Protocol:        uint32(e.Protocol),
+				RemotePortStart: uint32(e.RemotePortStart),
+				RemotePortStop:  uint32(e.RemotePortStop),
+				LocalPortStart:  uint32(e.LocalPortStart),
+				LocalPortStop:   uint32(e.LocalPortStop),
+				Action:          vpp_ipsec.SecurityPolicy_Action(e.Policy),
+			})
+		}
+	}
+
+	stream = ch.SendMultiRequest(&ipsec.IpsecTunnelProtectDump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	for {
+		details := &ipsec.IpsecTunnelProtectDetails{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("IpsecTunnelProtectDump failed: %w", err)
+		}
+		tun := details.Tun
+		config.TunProtects = append(config.TunProtects, &vpp_ipsec.TunnelProtection{
+			Interface:   ifNames[uint32(tun.SwIfIndex)],
+			SaOut:       []uint32{tun.SaOut},
+			SaIn:        tun.SaIn,
+			NextHopAddr: tun.Nh.String(),
+		})
+	}
+
+	return config, nil
+}
+
+func ipsecSA(e ipsec_types.IpsecSadEntryV3) *vpp_ipsec.SecurityAssociation {
+	sa := &vpp_ipsec.SecurityAssociation{
+		Index:          e.SadID,
+		Spi:            e.Spi,
+		Protocol:       vpp_ipsec.SecurityAssociation_ESP,
+		CryptoAlg:      vpp_ipsec.CryptoAlg(e.CryptoAlgorithm),
+		CryptoKey:      ipsecKey(e.CryptoKey),
+		CryptoSalt:     e.Salt,
+		IntegAlg:       vpp_ipsec.IntegAlg(e.IntegrityAlgorithm),
+		IntegKey:       ipsecKey(e.IntegrityKey),
+		UseEsn:         e.Flags&ipsec_types.IPSEC_API_SAD_FLAG_USE_ESN != 0,
+		UseAntiReplay:  e.Flags&ipsec_types.IPSEC_API_SAD_FLAG_USE_ANTI_REPLAY != 0,
+		EnableUdpEncap: e.Flags&ipsec_types.IPSEC_API_SAD_FLAG_UDP_ENCAP != 0,
+		TunnelSrcPort:  uint32(e.UDPSrcPort),
+		TunnelDstPort:  uint32(e.UDPDstPort),
+	}
+	if e.Protocol == ipsec_types.IPSEC_API_PROTO_AH {
+		sa.Protocol = vpp_ipsec.SecurityAssociation_AH
+	}
+	if e.Flags&ipsec_types.IPSEC_API_SAD_FLAG_IS_TUNNEL != 0 {
+		sa.TunnelSrcAddr = e.Tunnel.Src.String()
+		sa.TunnelDstAddr = e.Tunnel.Dst.String()
+	}
+	return sa
+}
+
+func ipsecKey(key ipsec_types.Key) string {
+	n := int(key.Length)
+	if n > len(key.Data) {
+		n = len(key.Data)
+	}
+	return hex.EncodeToString(key.Data[:n])
+}
@@ -0,0 +1,97 @@
+package binapi
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	govppapi "go.fd.io/govpp/api"
+
+	interfaces "go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/interface"
+
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+const interfaceEventsBufSize = 100
+
+// WatchInterfaceEventsChan subscribes to interface events via want_interface_events
+// and sends received events to the events channel until ctx is done. The events
+// channel is closed when watching stops.
+func WatchInterfaceEventsChan(ctx context.Context, ch govppapi.Channel, events chan<- api.InterfaceEvent) (err error) {
+	defer func() {
+		// some channel implementations (e.g. proxy) do not support notifications
+		if e := recover(); e != nil {
+			err = fmt.Errorf("subscribing notification failed: %v", e)
+		}
+	}()
+
+	notifCh := make(chan govppapi.Message, interfaceEventsBufSize)
+	sub, err := ch.SubscribeNotification(notifCh, &interfaces.SwInterfaceEvent{})
+	if err != nil {
+		return fmt.Errorf("subscribing notification failed: %w", err)
+	}
+
+	if err := wantInterfaceEventsChan(ch, true); err != nil {
+		if e := sub.Unsubscribe(); e != nil {
+			logrus.Debugf("unsubscribing interface events failed: %v", e)
+		}
+		return err
+	}
+
+	go func() {
+		defer close(events)
+		defer func() {
+			if err := wantInterfaceEventsChan(ch, false); err != nil {
+				logrus.Debugf("disabling interface events failed: %v", err)
+			}
+			if err := sub.Unsubscribe(); err != nil {
+				logrus.Debugf("unsubscribing interface events failed: %v", err)
+			}
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-notifCh:
+				e, ok := msg.(*interfaces.SwInterfaceEvent)
+				if !ok {
+					logrus.Debugf("unexpected notification message: %T", msg)
+					continue
+				}
+				event := api.InterfaceEvent{
+					Time:    time.Now(),
+					Index:   uint32(e.SwIfIndex),
+					Status:  vppIfStatusFlagsToStatus(e.Flags),
+					Deleted: e.Deleted,
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+func wantInterfaceEventsChan(ch govppapi.Channel, enable bool) error {
+	var enableDisable uint32
+	if enable {
+		enableDisable = 1
+	}
+	reply := &interfaces.WantInterfaceEventsReply{}
+	err := ch.SendRequest(&interfaces.WantInterfaceEvents{
+		EnableDisable: enableDisable,
+		PID:           uint32(os.Getpid()),
+	}).ReceiveReply(reply)
+	if err != nil {
+		return fmt.Errorf("WantInterfaceEvents failed: %w", err)
+	} else if e := govppapi.RetvalToVPPApiError(reply.Retval); e != nil {
+		return fmt.Errorf("WantInterfaceEvents failed: %w", e)
+	}
+	return nil
+}
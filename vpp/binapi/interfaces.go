@@ -0,0 +1,263 @@
+package binapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	govppapi "go.fd.io/govpp/api"
+
+	"go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/bond"
+	"go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/geneve"
+	"go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/gre"
+	interfaces "go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/interface"
+	"go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/interface_types"
+	"go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/ipip"
+	"go.ligato.io/vpp-agent/v3/plugins/vpp/binapi/vpp2210/vxlan"
+
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+func vppInterfaceDetails(iface *interfaces.SwInterfaceDetails) *api.Interface {
+	i := &api.Interface{
+		Index:      uint32(iface.SwIfIndex),
+		Name:       strings.Trim(iface.InterfaceName, "\x00"),
+		Tag:        strings.Trim(iface.Tag, "\x00"),
+		Type:       vppIfTypeToString(iface.Type),
+		DeviceType: iface.InterfaceDevType,
+		Status:     vppIfStatusFlagsToStatus(iface.Flags),
+		MTUs:       vppInterfaceMTU(iface.Mtu, iface.LinkMtu),
+		MAC:        iface.L2Address.String(),
+		Link: api.Link{
+			Speed:  uint64(iface.LinkSpeed),
+			Duplex: vppLinkDuplexToString(iface.LinkDuplex),
+		},
+	}
+	if iface.Type == interface_types.IF_API_TYPE_SUB && iface.SupSwIfIndex != uint32(iface.SwIfIndex) {
+		i.Sub = &api.SubInterface{
+			Parent:    iface.SupSwIfIndex,
+			SubID:     iface.SubID,
+			NumTags:   iface.SubNumberOfTags,
+			OuterVlan: iface.SubOuterVlanID,
+			InnerVlan: iface.SubInnerVlanID,
+		}
+	}
+	return i
+}
+
+// addInterfaceDetailsChan adds details from related dumps to interfaces.
+// Errors are only logged since some of the dumps require optional plugins.
+func addInterfaceDetailsChan(ch govppapi.Channel, list []*api.Interface) {
+	ifaces := make(map[uint32]*api.Interface, len(list))
+	for _, iface := range list {
+		ifaces[iface.Index] = iface
+	}
+
+	if err := addRxQueuesChan(ch, ifaces); err != nil {
+		logrus.Debugf("adding interface rx queues failed: %v", err)
+	}
+	if err := addBondsChan(ch, ifaces); err != nil {
+		logrus.Debugf("adding bond details failed: %v", err)
+	}
+	if err := addVxlanTunnelsChan(ch, ifaces); err != nil {
+		logrus.Debugf("adding vxlan tunnels failed: %v", err)
+	}
+	if err := addGreTunnelsChan(ch, ifaces); err != nil {
+		logrus.Debugf("adding gre tunnels failed: %v", err)
+	}
+	if err := addIpipTunnelsChan(ch, ifaces); err != nil {
+		logrus.Debugf("adding ipip tunnels failed: %v", err)
+	}
+	if hasGeneveInterface(list) {
+		if err := addGeneveTunnelsChan(ch, ifaces); err != nil {
+			logrus.Debugf("adding geneve tunnels failed: %v", err)
+		}
+	}
+}
+
+// hasGeneveInterface returns true if any of interfaces is geneve tunnel.
+func hasGeneveInterface(list []*api.Interface) bool {
+	for _, iface := range list {
+		if iface.DeviceType == "geneve" || strings.HasPrefix(iface.Name, "geneve_tunnel") {
+			return true
+		}
+	}
+	return false
+}
+
+func addRxQueuesChan(ch govppapi.Channel, ifaces map[uint32]*api.Interface) error {
+	stream := ch.SendMultiRequest(&interfaces.SwInterfaceRxPlacementDump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	for {
+		details := &interfaces.SwInterfaceRxPlacementDetails{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return fmt.Errorf("SwInterfaceRxPlacementDump failed: %w", err)
+		}
+		if iface, ok := ifaces[uint32(details.SwIfIndex)]; ok {
+			iface.RxQueues++
+		}
+	}
+	return nil
+}
+
+func addBondsChan(ch govppapi.Channel, ifaces map[uint32]*api.Interface) error {
+	var bonds []*bond.SwBondInterfaceDetails
+	stream := ch.SendMultiRequest(&bond.SwBondInterfaceDump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	for {
+		details := &bond.SwBondInterfaceDetails{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return fmt.Errorf("SwBondInterfaceDump failed: %w", err)
+		}
+		bonds = append(bonds, details)
+	}
+
+	for _, b := range bonds {
+		bondIdx := uint32(b.SwIfIndex)
+		info := &api.Bond{
+			ID:            b.ID,
+			Mode:          strings.ToLower(strings.TrimPrefix(b.Mode.String(), "BOND_API_MODE_")),
+			LoadBalance:   strings.ToLower(strings.TrimPrefix(b.Lb.String(), "BOND_API_LB_ALGO_")),
+			ActiveMembers: b.ActiveMembers,
+		}
+		stream := ch.SendMultiRequest(&bond.SwMemberInterfaceDump{
+			SwIfIndex: b.SwIfIndex,
+		})
+		for {
+			details := &bond.SwMemberInterfaceDetails{}
+			last, err := stream.ReceiveReply(details)
+			if last {
+				break
+			} else if err != nil {
+				return fmt.Errorf("SwMemberInterfaceDump failed: %w", err)
+			}
+			memberIdx := uint32(details.SwIfIndex)
+			info.Members = append(info.Members, api.BondMember{
+				Index:   memberIdx,
+				Name:    strings.Trim(details.InterfaceName, "\x00"),
+				Passive: details.IsPassive,
+				Weight:  details.Weight,
+			})
+			if member, ok := ifaces[memberIdx]; ok {
+				member.BondedTo = &bondIdx
+			}
+		}
+		if iface, ok := ifaces[bondIdx]; ok {
+			iface.Bond = info
+		}
+	}
+	return nil
+}
+
+func addVxlanTunnelsChan(ch govppapi.Channel, ifaces map[uint32]*api.Interface) error {
+	stream := ch.SendMultiRequest(&vxlan.VxlanTunnelDump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	for {
+		details := &vxlan.VxlanTunnelDetails{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return fmt.Errorf("VxlanTunnelDump failed: %w", err)
+		}
+		if iface, ok := ifaces[uint32(details.SwIfIndex)]; ok {
+			iface.Tunnel = &api.Tunnel{
+				Type: "vxlan",
+				Src:  details.SrcAddress.String(),
+				Dst:  details.DstAddress.String(),
+				VNI:  details.Vni,
+			}
+		}
+	}
+	return nil
+}
+
+func addGeneveTunnelsChan(ch govppapi.Channel, ifaces map[uint32]*api.Interface) error {
+	stream := ch.SendMultiRequest(&geneve.GeneveTunnelDump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	for {
+		details := &geneve.GeneveTunnelDetails{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return fmt.Errorf("GeneveTunnelDump failed: %w", err)
+		}
+		if iface, ok := ifaces[uint32(details.SwIfIndex)]; ok {
+			iface.Tunnel = &api.Tunnel{
+				Type: "geneve",
+				Src:  details.LocalAddress.String(),
+				Dst:  details.RemoteAddress.String(),
+				VNI:  details.Vni,
+			}
+		}
+	}
+	return nil
+}
+
+func addGreTunnelsChan(ch govppapi.Channel, ifaces map[uint32]*api.Interface) error {
+	stream := ch.SendMultiRequest(&gre.GreTunnelDump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	for {
+		details := &gre.GreTunnelDetails{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return fmt.Errorf("GreTunnelDump failed: %w", err)
+		}
+		if iface, ok := ifaces[uint32(details.Tunnel.SwIfIndex)]; ok {
+			iface.Tunnel = &api.Tunnel{
+				Type: "gre",
+				Src:  details.Tunnel.Src.String(),
+				Dst:  details.Tunnel.Dst.String(),
+			}
+		}
+	}
+	return nil
+}
+
+func addIpipTunnelsChan(ch govppapi.Channel, ifaces map[uint32]*api.Interface) error {
+	stream := ch.SendMultiRequest(&ipip.IpipTunnelDump{
+		SwIfIndex: ^interface_types.InterfaceIndex(0),
+	})
+	for {
+		details := &ipip.IpipTunnelDetails{}
+		last, err := stream.ReceiveReply(details)
+		if last {
+			break
+		} else if err != nil {
+			return fmt.Errorf("IpipTunnelDump failed: %w", err)
+		}
+		if iface, ok := ifaces[uint32(details.Tunnel.SwIfIndex)]; ok {
+			iface.Tunnel = &api.Tunnel{
+				Type: "ipip",
+				Src:  details.Tunnel.Src.String(),
+				Dst:  details.Tunnel.Dst.String(),
+			}
+		}
+	}
+	return nil
+}
+
+func vppLinkDuplexToString(duplex interface_types.LinkDuplex) string {
+	switch duplex {
+	case interface_types.LINK_DUPLEX_API_FULL:
+		return "full"
+	case interface_types.LINK_DUPLEX_API_HALF:
+		return "half"
+	default:
+		return ""
+	}
+}
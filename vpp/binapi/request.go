@@ -0,0 +1,91 @@
+package binapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	govppapi "go.fd.io/govpp/api"
+)
+
+// FindMessage returns message with name from list of messages.
+func FindMessage(msgs []govppapi.Message, name string) (govppapi.Message, bool) {
+	for _, msg := range msgs {
+		if msg.GetMessageName() == name {
+			return msg, true
+		}
+	}
+	return nil, false
+}
+
+// IsDumpRequest returns true if msg is a dump request expecting multiple replies.
+func IsDumpRequest(msg govppapi.Message) bool {
+	return strings.HasSuffix(msg.GetMessageName(), "_dump")
+}
+
+// ReplyMessageFor returns reply message for request msg from list of messages.
+func ReplyMessageFor(msgs []govppapi.Message, msg govppapi.Message) (govppapi.Message, error) {
+	name := msg.GetMessageName()
+	var replyName string
+	if IsDumpRequest(msg) {
+		replyName = strings.TrimSuffix(name, "_dump") + "_details"
+	} else {
+		replyName = name + "_reply"
+	}
+	reply, ok := FindMessage(msgs, replyName)
+	if !ok {
+		return nil, fmt.Errorf("reply message %q not found for %q", replyName, name)
+	}
+	return reply, nil
+}
+
+// NewMessage returns a new empty instance of message msg.
+func NewMessage(msg govppapi.Message) govppapi.Message {
+	return reflect.New(reflect.TypeOf(msg).Elem()).Interface().(govppapi.Message)
+}
+
+// SendRequestChan sends request message with name filled from JSON data
+// and returns received replies. Dump requests are sent as multi requests.
+func SendRequestChan(ch govppapi.Channel, msgs []govppapi.Message, name string, data []byte) ([]govppapi.Message, error) {
+	msg, ok := FindMessage(msgs, name)
+	if !ok {
+		return nil, fmt.Errorf("message %q not found", name)
+	}
+	if msg.GetMessageType() != govppapi.RequestMessage {
+		return nil, fmt.Errorf("message %q is not a request", name)
+	}
+	replyMsg, err := ReplyMessageFor(msgs, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	req := NewMessage(msg)
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, req); err != nil {
+			return nil, fmt.Errorf("decoding request data failed: %w", err)
+		}
+	}
+
+	if !IsDumpRequest(req) {
+		reply := NewMessage(replyMsg)
+		if err := ch.SendRequest(req).ReceiveReply(reply); err != nil {
+			return nil, fmt.Errorf("%s failed: %w", name, err)
+		}
+		return []govppapi.Message{reply}, nil
+	}
+
+	var replies []govppapi.Message
+	stream := ch.SendMultiRequest(req)
+	for {
+		reply := NewMessage(replyMsg)
+		last, err := stream.ReceiveReply(reply)
+		if last {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%s failed: %w", name, err)
+		}
+		replies = append(replies, reply)
+	}
+	return replies, nil
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sirupsen/logrus"
+
 	"go.ligato.io/vpp-probe/probe"
 	"go.ligato.io/vpp-probe/vpp/api"
 )
@@ -261,3 +263,166 @@ func ShowPluginsCLI(cli probe.CliExecutor) ([]api.Plugin, error) {
 
 	return plugins, nil
 }
+
+// vpp# show interface
+//               Name               Idx    State  MTU (L3/IP4/IP6/MPLS)     Counter          Count
+// local0                            0     down          0/0/0/0
+// memif1/1                          1      up          9000/0/0/0     rx packets                     5
+//                                                                     rx bytes                     420
+
+// vpp# show hardware-interfaces brief
+//               Name                Idx   Link  Hardware
+// local0                             0    down  local0
+// memif1/1                           1     up   memif1/1
+
+// ListInterfacesCLI returns list of interfaces with their status parsed
+// from the CLI output. It is used when binary API is not available.
+func ListInterfacesCLI(cli probe.CliExecutor) ([]*api.Interface, error) {
+	out, err := cli.RunCli("show interface")
+	if err != nil {
+		return nil, err
+	}
+	ifaces := parseShowInterface(out)
+
+	hwOut, err := cli.RunCli("show hardware-interfaces brief")
+	if err != nil {
+		return nil, err
+	}
+	links := parseShowHardwareBrief(hwOut)
+
+	for _, iface := range ifaces {
+		if linkUp, ok := links[iface.Name]; ok {
+			iface.Status.Link = linkUp
+		}
+	}
+
+	return ifaces, nil
+}
+
+func parseShowInterface(out string) []*api.Interface {
+	var ifaces []*api.Interface
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" || line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		idx, err := strconv.ParseUint(fields[1], 10, 32)
+		if err != nil {
+			continue
+		}
+		if fields[2] != "up" && fields[2] != "down" {
+			continue
+		}
+		iface := &api.Interface{
+			Index: uint32(idx),
+			Name:  fields[0],
+			Status: api.Status{
+				Up: fields[2] == "up",
+			},
+		}
+		if len(fields) > 3 {
+			var mtu api.MTU
+			if _, err := fmt.Sscanf(fields[3], "%d/%d/%d/%d", &mtu.L3, &mtu.IP4, &mtu.IP6, &mtu.MPLS); err == nil {
+				iface.MTUs = mtu
+			}
+		}
+		ifaces = append(ifaces, iface)
+	}
+	return ifaces
+}
+
+func parseShowHardwareBrief(out string) map[string]bool {
+	links := map[string]bool{}
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" || line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		if _, err := strconv.ParseUint(fields[1], 10, 32); err != nil {
+			continue
+		}
+		links[fields[0]] = fields[2] == "up"
+	}
+	return links
+}
+
+// AddInterfaceDetailsCLI adds details available only via CLI to interfaces:
+// enabled feature arcs and TX queue count. It runs one CLI command per
+// interface, interfaces for which it fails are skipped.
+func AddInterfaceDetailsCLI(cli probe.CliExecutor, ifaces []*api.Interface) error {
+	for _, iface := range ifaces {
+		out, err := cli.RunCli("show interface features " + iface.Name)
+		if err != nil {
+			logrus.Debugf("getting features of interface %v failed: %v", iface.Name, err)
+			continue
+		}
+		iface.Features = parseShowInterfaceFeatures(out)
+	}
+
+	out, err := cli.RunCli("show interface tx-placement")
+	if err != nil {
+		return err
+	}
+	txQueues := parseShowInterfaceTxPlacement(out)
+	for _, iface := range ifaces {
+		iface.TxQueues = txQueues[iface.Name]
+	}
+
+	return nil
+}
+
+func parseShowInterfaceFeatures(out string) []api.FeatureArc {
+	var arcs []api.FeatureArc
+	var arc *api.FeatureArc
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			arc = nil
+			if name := strings.TrimSuffix(line, ":"); name != line && !strings.Contains(name, " ") {
+				arcs = append(arcs, api.FeatureArc{Arc: name})
+				arc = &arcs[len(arcs)-1]
+			}
+			continue
+		}
+		feature := strings.TrimSpace(line)
+		if arc == nil || strings.HasPrefix(feature, "none") {
+			continue
+		}
+		arc.Features = append(arc.Features, feature)
+	}
+
+	// keep only arcs with enabled features
+	enabled := arcs[:0]
+	for _, a := range arcs {
+		if len(a.Features) > 0 {
+			enabled = append(enabled, a)
+		}
+	}
+	if len(enabled) == 0 {
+		return nil
+	}
+	return enabled
+}
+
+func parseShowInterfaceTxPlacement(out string) map[string]int {
+	queues := map[string]int{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		if _, err := strconv.ParseUint(fields[1], 10, 32); err != nil {
+			continue
+		}
+		queues[fields[0]]++
+	}
+	return queues
+}
@@ -1,11 +1,13 @@
 package vpp
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
 
 	"go.ligato.io/vpp-probe/probe"
+	"go.ligato.io/vpp-probe/vpp/api"
 )
 
 func Test_parseUptime(t *testing.T) {
@@ -141,6 +143,110 @@ func TestShowClockCLI(t *testing.T) {
 	}
 }
 
+func TestListInterfacesCLI(t *testing.T) {
+	cli := NewMockCLI(map[string]string{
+		"show interface": `              Name               Idx    State  MTU (L3/IP4/IP6/MPLS)     Counter          Count
+local0                            0     down          0/0/0/0
+memif1/1                          1      up          9000/0/0/0     rx packets                     5
+                                                                    rx bytes                     420
+tap0                              2      up          1500/0/0/0
+`,
+		"show hardware-interfaces brief": `              Name                Idx   Link  Hardware
+local0                             0    down  local0
+memif1/1                           1     up   memif1/1
+tap0                               2    down  tap0
+`,
+	})
+	want := []*api.Interface{
+		{Index: 0, Name: "local0"},
+		{Index: 1, Name: "memif1/1", Status: api.Status{Up: true, Link: true}, MTUs: api.MTU{L3: 9000}},
+		{Index: 2, Name: "tap0", Status: api.Status{Up: true}, MTUs: api.MTU{L3: 1500}},
+	}
+
+	got, err := ListInterfacesCLI(cli)
+	if err != nil {
+		t.Fatalf("ListInterfacesCLI() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListInterfacesCLI() got = %+v, want %+v", got, want)
+	}
+}
+
+func TestAddInterfaceDetailsCLI(t *testing.T) {
+	cli := NewMockCLI(map[string]string{
+		"show interface features tap0": `Feature paths configured on tap0...
+
+ip4-unicast:
+  ip4-not-enabled
+
+ip4-output:
+  acl-plugin-out-ip4-fa
+  interface-output
+
+l2-input-nonip:
+ none configured
+
+Driver:
+  none
+`,
+		"show interface tx-placement": `  Name                       Queue-ID                 Threads          Shared
+tap0                          0                         0-1              no
+tap0                          1                         2                no
+`,
+	})
+	ifaces := []*api.Interface{{Index: 1, Name: "tap0"}}
+	want := []*api.Interface{{
+		Index:    1,
+		Name:     "tap0",
+		TxQueues: 2,
+		Features: []api.FeatureArc{
+			{Arc: "ip4-unicast", Features: []string{"ip4-not-enabled"}},
+			{Arc: "ip4-output", Features: []string{"acl-plugin-out-ip4-fa", "interface-output"}},
+		},
+	}}
+
+	if err := AddInterfaceDetailsCLI(cli, ifaces); err != nil {
+		t.Fatalf("AddInterfaceDetailsCLI() error = %v", err)
+	}
+	if !reflect.DeepEqual(ifaces, want) {
+		t.Errorf("AddInterfaceDetailsCLI() got = %+v, want %+v", ifaces, want)
+	}
+}
+
+func TestAddInterfaceDetailsCLIError(t *testing.T) {
+	cli := &failingCLI{
+		MockCLI: NewMockCLI(map[string]string{
+			"show interface features tap0": "ip4-output:\n  interface-output\n",
+			"show interface tx-placement":  "tap0  0  0  no\ntap1  0  0  no\n",
+		}),
+		fail: "show interface features tap1",
+	}
+	ifaces := []*api.Interface{{Index: 1, Name: "tap0"}, {Index: 2, Name: "tap1"}}
+
+	if err := AddInterfaceDetailsCLI(cli, ifaces); err != nil {
+		t.Fatalf("AddInterfaceDetailsCLI() error = %v", err)
+	}
+	if len(ifaces[0].Features) != 1 || ifaces[1].Features != nil {
+		t.Errorf("unexpected features: %+v, %+v", ifaces[0].Features, ifaces[1].Features)
+	}
+	if ifaces[0].TxQueues != 1 || ifaces[1].TxQueues != 1 {
+		t.Errorf("unexpected tx queues: %d, %d", ifaces[0].TxQueues, ifaces[1].TxQueues)
+	}
+}
+
+// failingCLI fails for command fail.
+type failingCLI struct {
+	*MockCLI
+	fail string
+}
+
+func (m *failingCLI) RunCli(cmd string) (string, error) {
+	if cmd == m.fail {
+		return "", fmt.Errorf("command %q failed", cmd)
+	}
+	return m.MockCLI.RunCli(cmd)
+}
+
 type MockCLI struct {
 	replymap map[string]string
 }
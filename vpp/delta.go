@@ -0,0 +1,115 @@
+package vpp
+
+import (
+	"sort"
+
+	govppapi "go.fd.io/govpp/api"
+
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+// StatsDelta contains non-zero increments of counters between two stats snapshots.
+type StatsDelta struct {
+	Errors     map[string]uint64 `json:",omitempty"`
+	Interfaces []InterfaceDelta  `json:",omitempty"`
+	Nodes      []NodeDelta       `json:",omitempty"`
+}
+
+// InterfaceDelta contains increments of interface counters.
+type InterfaceDelta struct {
+	Interface string
+
+	RxPackets uint64 `json:",omitempty"`
+	TxPackets uint64 `json:",omitempty"`
+	Drops     uint64 `json:",omitempty"`
+	Punts     uint64 `json:",omitempty"`
+	RxErrors  uint64 `json:",omitempty"`
+	TxErrors  uint64 `json:",omitempty"`
+	RxNoBuf   uint64 `json:",omitempty"`
+	RxMiss    uint64 `json:",omitempty"`
+}
+
+// NodeDelta contains increments of node counters.
+type NodeDelta struct {
+	Node string
+
+	Calls    uint64
+	Vectors  uint64
+	Suspends uint64 `json:",omitempty"`
+	Clocks   uint64 `json:",omitempty"`
+}
+
+// IsEmpty returns true if no counter changed.
+func (d *StatsDelta) IsEmpty() bool {
+	return len(d.Errors) == 0 && len(d.Interfaces) == 0 && len(d.Nodes) == 0
+}
+
+// DiffStats computes increments of error, interface and node counters between
+// before and after. Interfaces and nodes are included only if packets were
+// dropped/received/sent or vectors processed. Counters that decreased (e.g.
+// were cleared) are treated as unchanged.
+func DiffStats(before, after *api.VppStats) *StatsDelta {
+	delta := &StatsDelta{}
+	if before == nil || after == nil {
+		return delta
+	}
+	diff := func(prev, curr uint64) uint64 {
+		if curr <= prev {
+			return 0
+		}
+		return curr - prev
+	}
+
+	for name, value := range after.Counters {
+		if d := diff(before.Counters[name], value); d > 0 {
+			if delta.Errors == nil {
+				delta.Errors = map[string]uint64{}
+			}
+			delta.Errors[name] = d
+		}
+	}
+
+	for name, c := range after.Interfaces {
+		p := before.Interfaces[name]
+		d := InterfaceDelta{
+			Interface: name,
+			RxPackets: diff(counterOrZero(p.Rx).Packets, counterOrZero(c.Rx).Packets),
+			TxPackets: diff(counterOrZero(p.Tx).Packets, counterOrZero(c.Tx).Packets),
+			Drops:     diff(p.Drops, c.Drops),
+			Punts:     diff(p.Punts, c.Punts),
+			RxErrors:  diff(p.RxErrors, c.RxErrors),
+			TxErrors:  diff(p.TxErrors, c.TxErrors),
+			RxNoBuf:   diff(p.RxNoBuf, c.RxNoBuf),
+			RxMiss:    diff(p.RxMiss, c.RxMiss),
+		}
+		if d != (InterfaceDelta{Interface: name}) {
+			delta.Interfaces = append(delta.Interfaces, d)
+		}
+	}
+	sort.Slice(delta.Interfaces, func(i, j int) bool {
+		return delta.Interfaces[i].Interface < delta.Interfaces[j].Interface
+	})
+
+	prevNodes := make(map[string]govppapi.NodeCounters, len(before.Nodes))
+	for _, n := range before.Nodes {
+		prevNodes[n.NodeName] = n
+	}
+	for _, n := range after.Nodes {
+		p := prevNodes[n.NodeName]
+		d := NodeDelta{
+			Node:     n.NodeName,
+			Calls:    diff(p.Calls, n.Calls),
+			Vectors:  diff(p.Vectors, n.Vectors),
+			Suspends: diff(p.Suspends, n.Suspends),
+			Clocks:   diff(p.Clocks, n.Clocks),
+		}
+		if d.Vectors > 0 {
+			delta.Nodes = append(delta.Nodes, d)
+		}
+	}
+	sort.Slice(delta.Nodes, func(i, j int) bool {
+		return delta.Nodes[i].Node < delta.Nodes[j].Node
+	})
+
+	return delta
+}
@@ -0,0 +1,59 @@
+package vpp
+
+import (
+	"reflect"
+	"testing"
+
+	govppapi "go.fd.io/govpp/api"
+
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+func TestDiffStats(t *testing.T) {
+	before := &api.VppStats{
+		Interfaces: map[string]api.InterfaceStats{
+			"tap0":   {Rx: &api.InterfaceCounter{Packets: 10}, Drops: 1},
+			"local0": {},
+		},
+		Counters: map[string]uint64{
+			"ip4-input/ip4 ttl <= 1": 5,
+			"cleared":                100,
+		},
+		Nodes: []govppapi.NodeCounters{
+			{NodeName: "ip4-input", Calls: 10, Vectors: 20},
+			{NodeName: "idle", Calls: 5},
+		},
+	}
+	after := &api.VppStats{
+		Interfaces: map[string]api.InterfaceStats{
+			"tap0":   {Rx: &api.InterfaceCounter{Packets: 15}, Drops: 3},
+			"local0": {},
+		},
+		Counters: map[string]uint64{
+			"ip4-input/ip4 ttl <= 1":         7,
+			"ip4-arp/address overflow drops": 1,
+			"cleared":                        10,
+		},
+		Nodes: []govppapi.NodeCounters{
+			{NodeName: "ip4-input", Calls: 12, Vectors: 25, Clocks: 100},
+			{NodeName: "idle", Calls: 6},
+		},
+	}
+	want := &StatsDelta{
+		Errors: map[string]uint64{
+			"ip4-input/ip4 ttl <= 1":         2,
+			"ip4-arp/address overflow drops": 1,
+		},
+		Interfaces: []InterfaceDelta{
+			{Interface: "tap0", RxPackets: 5, Drops: 2},
+		},
+		Nodes: []NodeDelta{
+			{Node: "ip4-input", Calls: 2, Vectors: 5, Clocks: 100},
+		},
+	}
+
+	got := DiffStats(before, after)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffStats() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,152 @@
+package vpp
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"go.ligato.io/vpp-probe/vpp/api"
+	"go.ligato.io/vpp-probe/vpp/binapi"
+)
+
+// DefaultEventsPollInterval is the interval used for polling interface status
+// when interface events cannot be subscribed via binary API.
+const DefaultEventsPollInterval = time.Second * 2
+
+// WatchInterfaceEvents returns channel with interface status changes which
+// is closed when ctx is done. It subscribes to interface events via binary API
+// and falls back to polling interface status every interval for instances
+// where the subscription is not possible (e.g. proxy or CLI only).
+func (v *Instance) WatchInterfaceEvents(ctx context.Context, interval time.Duration) (<-chan api.InterfaceEvent, error) {
+	log := logrus.WithField("instance", v.ID())
+
+	if interval <= 0 {
+		interval = DefaultEventsPollInterval
+	}
+
+	names := map[uint32]string{}
+	for _, iface := range v.vppInterfaces {
+		names[iface.Index] = iface.Name
+	}
+
+	if v.api != nil {
+		rawEvents := make(chan api.InterfaceEvent)
+		err := binapi.WatchInterfaceEventsChan(ctx, v.api, rawEvents)
+		if err == nil {
+			log.Debugf("subscribed to interface events via binary API")
+			events := make(chan api.InterfaceEvent)
+			go func() {
+				defer close(events)
+				for event := range rawEvents {
+					name, ok := names[event.Index]
+					if !ok {
+						if ifaces, err := v.ListInterfaces(); err == nil {
+							for _, iface := range ifaces {
+								names[iface.Index] = iface.Name
+							}
+						}
+						name = names[event.Index]
+					}
+					event.Name = name
+					select {
+					case events <- event:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+			return events, nil
+		}
+		log.Debugf("subscribing to interface events failed (%v), falling back to polling", err)
+	}
+
+	prev, err := v.listInterfacesStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan api.InterfaceEvent)
+	go func() {
+		defer close(events)
+
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+			curr, err := v.listInterfacesStatus()
+			if err != nil {
+				log.Debugf("polling interface status failed: %v", err)
+				continue
+			}
+			for _, event := range diffInterfacesStatus(prev, curr) {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			prev = curr
+		}
+	}()
+
+	return events, nil
+}
+
+func (v *Instance) listInterfacesStatus() (map[uint32]*api.Interface, error) {
+	var (
+		list []*api.Interface
+		err  error
+	)
+	if v.api != nil {
+		list, err = v.ListInterfaces()
+	} else {
+		list, err = ListInterfacesCLI(v.cli)
+	}
+	if err != nil {
+		return nil, err
+	}
+	ifaces := make(map[uint32]*api.Interface, len(list))
+	for _, iface := range list {
+		ifaces[iface.Index] = iface
+	}
+	return ifaces, nil
+}
+
+func diffInterfacesStatus(prev, curr map[uint32]*api.Interface) []api.InterfaceEvent {
+	now := time.Now()
+
+	var events []api.InterfaceEvent
+	for idx, iface := range curr {
+		if old, ok := prev[idx]; ok && old.Status == iface.Status {
+			continue
+		}
+		events = append(events, api.InterfaceEvent{
+			Time:   now,
+			Index:  idx,
+			Name:   iface.Name,
+			Status: iface.Status,
+		})
+	}
+	for idx, iface := range prev {
+		if _, ok := curr[idx]; ok {
+			continue
+		}
+		events = append(events, api.InterfaceEvent{
+			Time:    now,
+			Index:   idx,
+			Name:    iface.Name,
+			Deleted: true,
+		})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Index < events[j].Index
+	})
+	return events
+}
@@ -0,0 +1,85 @@
+package vpp
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+func TestDiffInterfacesStatus(t *testing.T) {
+	iface := func(idx uint32, name string, up, link bool) *api.Interface {
+		return &api.Interface{Index: idx, Name: name, Status: api.Status{Up: up, Link: link}}
+	}
+	ifaces := func(list ...*api.Interface) map[uint32]*api.Interface {
+		m := make(map[uint32]*api.Interface, len(list))
+		for _, i := range list {
+			m[i.Index] = i
+		}
+		return m
+	}
+
+	tests := []struct {
+		name string
+		prev map[uint32]*api.Interface
+		curr map[uint32]*api.Interface
+		want []api.InterfaceEvent
+	}{
+		{
+			name: "no change",
+			prev: ifaces(iface(0, "local0", false, false), iface(1, "tap0", true, true)),
+			curr: ifaces(iface(0, "local0", false, false), iface(1, "tap0", true, true)),
+		},
+		{
+			name: "admin up",
+			prev: ifaces(iface(1, "tap0", false, false)),
+			curr: ifaces(iface(1, "tap0", true, false)),
+			want: []api.InterfaceEvent{{Index: 1, Name: "tap0", Status: api.Status{Up: true}}},
+		},
+		{
+			name: "admin down",
+			prev: ifaces(iface(1, "tap0", true, false)),
+			curr: ifaces(iface(1, "tap0", false, false)),
+			want: []api.InterfaceEvent{{Index: 1, Name: "tap0"}},
+		},
+		{
+			name: "link up",
+			prev: ifaces(iface(1, "tap0", true, false)),
+			curr: ifaces(iface(1, "tap0", true, true)),
+			want: []api.InterfaceEvent{{Index: 1, Name: "tap0", Status: api.Status{Up: true, Link: true}}},
+		},
+		{
+			name: "link down",
+			prev: ifaces(iface(1, "tap0", true, true)),
+			curr: ifaces(iface(1, "tap0", true, false)),
+			want: []api.InterfaceEvent{{Index: 1, Name: "tap0", Status: api.Status{Up: true}}},
+		},
+		{
+			name: "interface added",
+			prev: ifaces(iface(1, "tap0", true, true)),
+			curr: ifaces(iface(1, "tap0", true, true), iface(2, "memif0/0", false, false)),
+			want: []api.InterfaceEvent{{Index: 2, Name: "memif0/0"}},
+		},
+		{
+			name: "interface deleted",
+			prev: ifaces(iface(1, "tap0", true, true), iface(2, "memif0/0", true, true)),
+			curr: ifaces(iface(1, "tap0", true, true)),
+			want: []api.InterfaceEvent{{Index: 2, Name: "memif0/0", Deleted: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffInterfacesStatus(tt.prev, tt.curr)
+			for i := range got {
+				if got[i].Time.IsZero() {
+					t.Errorf("event #%d has zero time", i)
+				}
+				got[i].Time = time.Time{}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffInterfacesStatus()\n got: %+v\nwant: %+v", got, tt.want)
+			}
+		})
+	}
+}
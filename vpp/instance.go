@@ -28,7 +28,11 @@ type Instance struct {
 	api   govppapi.Channel
 	stats govppapi.StatsProvider
 
+	binapiMsgs []govppapi.Message
+
 	agent *agent.Instance
+	// nativeConfig is config built from VPP state for instances without agent
+	nativeConfig *agent.Config
 
 	status        *APIStatus
 	vppInfo       api.VppInfo
@@ -45,6 +49,19 @@ func NewInstance(probe probe.Handler) (*Instance, error) {
 	return h, nil
 }
 
+// NewOfflineInstance returns a new Instance that is not connected to VPP
+// with config set to config.
+func NewOfflineInstance(id string, metadata map[string]string, config *agent.Config) *Instance {
+	return &Instance{
+		handler: &dummyHandler{
+			id:       id,
+			metadata: metadata,
+		},
+		status:       &APIStatus{},
+		nativeConfig: config,
+	}
+}
+
 type instanceData struct {
 	ID       string
 	Metadata map[string]string
@@ -52,6 +69,9 @@ type instanceData struct {
 	VppInfo  api.VppInfo
 	VppStats *api.VppStats
 	Agent    *agent.Instance
+	Config   *agent.Config `json:",omitempty"`
+
+	VppInterfaces []*api.Interface `json:",omitempty"`
 }
 
 func (v *Instance) MarshalJSON() ([]byte, error) {
@@ -62,6 +82,9 @@ func (v *Instance) MarshalJSON() ([]byte, error) {
 		Agent:    v.agent,
 		Status:   v.status,
 		VppStats: v.vppStats,
+		Config:   v.nativeConfig,
+
+		VppInterfaces: v.vppInterfaces,
 	}
 	return json.Marshal(instance)
 }
@@ -78,6 +101,9 @@ func (v *Instance) UnmarshalJSON(data []byte) error {
 	v.vppInfo = instance.VppInfo
 	v.agent = instance.Agent
 	v.status = instance.Status
+	v.vppStats = instance.VppStats
+	v.nativeConfig = instance.Config
+	v.vppInterfaces = instance.VppInterfaces
 	return nil
 }
 
@@ -113,6 +139,12 @@ func (v *Instance) VppInterfaces() []*api.Interface {
 	return v.vppInterfaces
 }
 
+// BinapiMessages returns list of binary API messages for the VPP version
+// of the instance.
+func (v *Instance) BinapiMessages() []govppapi.Message {
+	return v.binapiMsgs
+}
+
 func (v *Instance) Init() (err error) {
 	l := logrus.WithFields(map[string]interface{}{
 		"instance": v.ID(),
@@ -161,6 +193,16 @@ func (v *Instance) Init() (err error) {
 	return nil
 }
 
+// AddInterfaceDetails adds details available only via CLI to interfaces.
+// It runs CLI command for each interface so it should be used only when
+// the details are displayed.
+func (v *Instance) AddInterfaceDetails(ifaces []*api.Interface) error {
+	if v.cli == nil {
+		return ErrCLIUnavailable
+	}
+	return AddInterfaceDetailsCLI(v.cli, ifaces)
+}
+
 func (v *Instance) RunCli(cmd string) (string, error) {
 	if v.cli == nil {
 		return "", ErrCLIUnavailable
@@ -290,6 +332,7 @@ func (v *Instance) initBinapi() (err error) {
 	}
 
 	v.api = ch
+	v.binapiMsgs = msgList.AllMessages()
 	return nil
 }
 
@@ -0,0 +1,248 @@
+package vpp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	kvs "go.ligato.io/vpp-agent/v3/plugins/kvscheduler/api"
+	vpp_interfaces "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/interfaces"
+
+	"go.ligato.io/vpp-probe/vpp/agent"
+	"go.ligato.io/vpp-probe/vpp/api"
+	"go.ligato.io/vpp-probe/vpp/binapi"
+)
+
+// Config returns config of the instance. The config retrieved from agent is
+// preferred and config built from VPP state is used for instances without
+// agent.
+func (v *Instance) Config() *agent.Config {
+	if v.agent != nil && v.agent.Config != nil {
+		return v.agent.Config
+	}
+	return v.nativeConfig
+}
+
+// UpdateConfig retrieves config from agent or builds it from VPP state
+// if agent is not running.
+func (v *Instance) UpdateConfig() error {
+	if v.agent != nil {
+		return v.agent.UpdateInstanceInfo()
+	}
+	config, err := v.BuildNativeConfig()
+	if err != nil {
+		return fmt.Errorf("building config from VPP failed: %w", err)
+	}
+	v.nativeConfig = config
+	return nil
+}
+
+// BuildNativeConfig builds config using only VPP binary API. All values
+// have southbound origin.
+func (v *Instance) BuildNativeConfig() (*agent.Config, error) {
+	if v.api == nil {
+		return nil, ErrAPIUnavailable
+	}
+	log := logrus.WithField("instance", v.ID())
+
+	ifaces, err := binapi.ListInterfacesChan(v.api)
+	if err != nil {
+		return nil, err
+	}
+	ifNames := make(map[uint32]string, len(ifaces))
+	for _, iface := range ifaces {
+		ifNames[iface.Index] = iface.Name
+	}
+
+	memifs, err := binapi.DumpMemifsChan(v.api)
+	if err != nil {
+		log.Debugf("dumping memifs failed: %v", err)
+	}
+	taps, err := binapi.DumpTapsChan(v.api)
+	if err != nil {
+		log.Debugf("dumping taps failed: %v", err)
+	}
+	afpackets, err := binapi.DumpAfPacketsChan(v.api)
+	if err != nil {
+		log.Debugf("dumping af_packets failed: %v", err)
+	}
+	vhostUsers, err := binapi.DumpVhostUsersChan(v.api)
+	if err != nil {
+		log.Debugf("dumping vhost-user interfaces failed: %v", err)
+	}
+	wireguard, err := binapi.DumpWireguardChan(v.api, ifNames)
+	if err != nil {
+		log.Debugf("dumping wireguard failed: %v", err)
+		wireguard = &binapi.WireguardConfig{}
+	}
+
+	var config agent.Config
+	for _, iface := range ifaces {
+		if iface.Index == 0 {
+			continue
+		}
+		value := nativeInterface(iface, ifNames)
+		metadata := map[string]interface{}{
+			"SwIfIndex":    iface.Index,
+			"InternalName": iface.Name,
+			"linkstate":    iface.Status.Link,
+		}
+		if link, ok := memifs[iface.Index]; ok {
+			value.Type = vpp_interfaces.Interface_MEMIF
+			value.Link = &vpp_interfaces.Interface_Memif{Memif: link}
+		} else if link, ok := taps[iface.Index]; ok {
+			value.Type = vpp_interfaces.Interface_TAP
+			value.Link = &vpp_interfaces.Interface_Tap{Tap: link}
+			metadata["TAPHostIfName"] = link.HostIfName
+		} else if link, ok := afpackets[iface.Index]; ok {
+			value.Type = vpp_interfaces.Interface_AF_PACKET
+			value.Link = &vpp_interfaces.Interface_Afpacket{Afpacket: link}
+		} else if link, ok := wireguard.Links[iface.Index]; ok {
+			value.Type = vpp_interfaces.Interface_WIREGUARD_TUNNEL
+			value.Link = &vpp_interfaces.Interface_Wireguard{Wireguard: link}
+			metadata["PublicKey"] = wireguard.PublicKeys[iface.Index]
+		} else if socket, ok := vhostUsers[iface.Index]; ok {
+			// vhost-user has no model in agent
+			metadata["VhostSocket"] = socket
+		} else if iface.Tunnel != nil && value.GetLink() == nil {
+			// tunnel types without model in agent (geneve)
+			metadata["TunnelType"] = iface.Tunnel.Type
+			metadata["TunnelSrc"] = iface.Tunnel.Src
+			metadata["TunnelDst"] = iface.Tunnel.Dst
+			metadata["TunnelVNI"] = iface.Tunnel.VNI
+		}
+		config.VPP.Interfaces = append(config.VPP.Interfaces, agent.VppInterface{
+			KVData: nativeKVData(vpp_interfaces.InterfaceKey(value.Name), metadata),
+			Value:  value,
+		})
+	}
+
+	for _, peer := range wireguard.Peers {
+		config.VPP.WgPeers = append(config.VPP.WgPeers, agent.VppWgPeer{
+			KVData: nativeKVData("", nil),
+			Value:  peer,
+		})
+	}
+
+	routes, err := binapi.DumpRoutesChan(v.api, ifNames)
+	if err != nil {
+		log.Debugf("dumping routes failed: %v", err)
+	}
+	for _, route := range routes {
+		config.VPP.Routes = append(config.VPP.Routes, agent.VppRoute{
+			KVData: nativeKVData("", nil),
+			Value:  route,
+		})
+	}
+
+	xconnects, err := binapi.DumpL2XconnectsChan(v.api, ifNames)
+	if err != nil {
+		log.Debugf("dumping L2 xconnects failed: %v", err)
+	}
+	for _, xc := range xconnects {
+		config.VPP.L2XConnects = append(config.VPP.L2XConnects, agent.VppL2XConnect{
+			KVData: nativeKVData("", nil),
+			Value:  xc,
+		})
+	}
+
+	ipsec, err := binapi.DumpIPSecChan(v.api, ifNames)
+	if err != nil {
+		log.Debugf("dumping IPSec failed: %v", err)
+	} else {
+		for _, sa := range ipsec.SAs {
+			config.VPP.IPSecSAs = append(config.VPP.IPSecSAs, agent.VppIPSecSA{
+				KVData: nativeKVData("", nil),
+				Value:  sa,
+			})
+		}
+		for _, spd := range ipsec.SPDs {
+			config.VPP.IPSecSPDs = append(config.VPP.IPSecSPDs, agent.VppIPSecSPD{
+				KVData: nativeKVData("", nil),
+				Value:  spd,
+			})
+		}
+		for _, sp := range ipsec.SPs {
+			config.VPP.IPSecSPs = append(config.VPP.IPSecSPs, agent.VppIPSecSP{
+				KVData: nativeKVData("", nil),
+				Value:  sp,
+			})
+		}
+		for _, tp := range ipsec.TunProtects {
+			config.VPP.IPSecTunProtects = append(config.VPP.IPSecTunProtects, agent.VppIPSecTunProtect{
+				KVData: nativeKVData("", nil),
+				Value:  tp,
+			})
+		}
+	}
+
+	if err := agent.RetrieveMetadata(v.handler, &config); err != nil {
+		log.Debugf("retrieving metadata failed: %v", err)
+	}
+
+	return &config, nil
+}
+
+func nativeKVData(key string, metadata map[string]interface{}) agent.KVData {
+	return agent.KVData{
+		Key:      key,
+		Metadata: metadata,
+		Origin:   agent.ValueOrigin(kvs.FromSB),
+	}
+}
+
+// nativeInterface returns interface model for VPP interface. Type is set
+// for interfaces which can be recognized from interface details, link of
+// memif, tap and af_packet interfaces is set by caller.
+func nativeInterface(iface *api.Interface, ifNames map[uint32]string) *vpp_interfaces.Interface {
+	value := &vpp_interfaces.Interface{
+		Name:        iface.Name,
+		Enabled:     iface.Status.Up,
+		PhysAddress: iface.MAC,
+		IpAddresses: iface.IPs,
+		Vrf:         uint32(iface.VRF.IP4),
+		Mtu:         uint32(iface.MTUs.L3),
+	}
+	switch {
+	case iface.Sub != nil:
+		value.Type = vpp_interfaces.Interface_SUB_INTERFACE
+		value.Link = &vpp_interfaces.Interface_Sub{Sub: &vpp_interfaces.SubInterface{
+			ParentName: ifNames[iface.Sub.Parent],
+			SubId:      iface.Sub.SubID,
+		}}
+	case iface.Bond != nil:
+		value.Type = vpp_interfaces.Interface_BOND_INTERFACE
+		bond := &vpp_interfaces.BondLink{Id: iface.Bond.ID}
+		for _, member := range iface.Bond.Members {
+			bond.BondedInterfaces = append(bond.BondedInterfaces, &vpp_interfaces.BondLink_BondedInterface{
+				Name:      member.Name,
+				IsPassive: member.Passive,
+			})
+		}
+		value.Link = &vpp_interfaces.Interface_Bond{Bond: bond}
+	case iface.Tunnel != nil && iface.Tunnel.Type == "vxlan":
+		value.Type = vpp_interfaces.Interface_VXLAN_TUNNEL
+		value.Link = &vpp_interfaces.Interface_Vxlan{Vxlan: &vpp_interfaces.VxlanLink{
+			SrcAddress: iface.Tunnel.Src,
+			DstAddress: iface.Tunnel.Dst,
+			Vni:        iface.Tunnel.VNI,
+		}}
+	case iface.Tunnel != nil && iface.Tunnel.Type == "gre":
+		value.Type = vpp_interfaces.Interface_GRE_TUNNEL
+		value.Link = &vpp_interfaces.Interface_Gre{Gre: &vpp_interfaces.GreLink{
+			SrcAddr: iface.Tunnel.Src,
+			DstAddr: iface.Tunnel.Dst,
+		}}
+	case iface.Tunnel != nil && iface.Tunnel.Type == "ipip":
+		value.Type = vpp_interfaces.Interface_IPIP_TUNNEL
+		value.Link = &vpp_interfaces.Interface_Ipip{Ipip: &vpp_interfaces.IPIPLink{
+			SrcAddr: iface.Tunnel.Src,
+			DstAddr: iface.Tunnel.Dst,
+		}}
+	case strings.HasPrefix(iface.Name, "loop"):
+		value.Type = vpp_interfaces.Interface_SOFTWARE_LOOPBACK
+	case iface.DeviceType == "dpdk":
+		value.Type = vpp_interfaces.Interface_DPDK
+	}
+	return value
+}
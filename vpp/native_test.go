@@ -0,0 +1,45 @@
+package vpp
+
+import (
+	"testing"
+
+	vpp_interfaces "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/interfaces"
+
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+func TestNativeInterface(t *testing.T) {
+	ifNames := map[uint32]string{1: "GigabitEthernet0/8/0"}
+
+	tests := []struct {
+		name  string
+		iface *api.Interface
+		want  vpp_interfaces.Interface_Type
+	}{
+		{"loopback", &api.Interface{Name: "loop0"}, vpp_interfaces.Interface_SOFTWARE_LOOPBACK},
+		{"dpdk", &api.Interface{Name: "GigabitEthernet0/8/0", DeviceType: "dpdk"}, vpp_interfaces.Interface_DPDK},
+		{"sub", &api.Interface{Name: "GigabitEthernet0/8/0.10", Sub: &api.SubInterface{Parent: 1, SubID: 10}}, vpp_interfaces.Interface_SUB_INTERFACE},
+		{"vxlan", &api.Interface{Name: "vxlan_tunnel0", Tunnel: &api.Tunnel{Type: "vxlan", Src: "10.0.0.1", Dst: "10.0.0.2", VNI: 13}}, vpp_interfaces.Interface_VXLAN_TUNNEL},
+		{"unknown", &api.Interface{Name: "memif0/0"}, vpp_interfaces.Interface_UNDEFINED_TYPE},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nativeInterface(tt.iface, ifNames)
+			if got.GetType() != tt.want {
+				t.Errorf("nativeInterface() type = %v, want %v", got.GetType(), tt.want)
+			}
+			if got.GetName() != tt.iface.Name {
+				t.Errorf("nativeInterface() name = %q, want %q", got.GetName(), tt.iface.Name)
+			}
+		})
+	}
+
+	sub := nativeInterface(tests[2].iface, ifNames)
+	if parent := sub.GetSub().GetParentName(); parent != "GigabitEthernet0/8/0" {
+		t.Errorf("expected parent name GigabitEthernet0/8/0, got %q", parent)
+	}
+	vxlan := nativeInterface(tests[3].iface, ifNames)
+	if vni := vxlan.GetVxlan().GetVni(); vni != 13 {
+		t.Errorf("expected VNI 13, got %d", vni)
+	}
+}
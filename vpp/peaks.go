@@ -0,0 +1,86 @@
+package vpp
+
+import (
+	"sort"
+	"time"
+)
+
+// RatePeak is a maximum rate and time when it was reached.
+type RatePeak struct {
+	Rate float64
+	Time time.Time
+}
+
+func (p *RatePeak) update(rate float64, t time.Time) {
+	if rate > p.Rate {
+		p.Rate = rate
+		p.Time = t
+	}
+}
+
+// InterfacePeaks contains peak rates of interface counters.
+type InterfacePeaks struct {
+	Interface string
+
+	RxPps    RatePeak
+	RxBps    RatePeak
+	TxPps    RatePeak
+	TxBps    RatePeak
+	DropRate RatePeak
+}
+
+// StatsPeaks summarizes peak rates over sequence of stats samples.
+type StatsPeaks struct {
+	Start      time.Time
+	End        time.Time
+	Samples    int
+	Interfaces []InterfacePeaks
+	Errors     map[string]RatePeak `json:",omitempty"`
+}
+
+// SummarizePeaks computes rates between consecutive samples, which are
+// expected to be ordered by time, and returns peak rates.
+func SummarizePeaks(samples []StatsSample) *StatsPeaks {
+	peaks := &StatsPeaks{
+		Samples: len(samples),
+	}
+	if len(samples) == 0 {
+		return peaks
+	}
+	peaks.Start = samples[0].Time
+	peaks.End = samples[len(samples)-1].Time
+
+	ifaces := map[string]*InterfacePeaks{}
+	for i := 1; i < len(samples); i++ {
+		rates := ComputeRates(samples[i-1], samples[i])
+		t := samples[i].Time
+		for _, r := range rates.Interfaces {
+			p, ok := ifaces[r.Interface]
+			if !ok {
+				p = &InterfacePeaks{Interface: r.Interface}
+				ifaces[r.Interface] = p
+			}
+			p.RxPps.update(r.RxPps, t)
+			p.RxBps.update(r.RxBps, t)
+			p.TxPps.update(r.TxPps, t)
+			p.TxBps.update(r.TxBps, t)
+			p.DropRate.update(r.DropRate, t)
+		}
+		for name, rate := range rates.Errors {
+			if peaks.Errors == nil {
+				peaks.Errors = map[string]RatePeak{}
+			}
+			p := peaks.Errors[name]
+			p.update(rate, t)
+			peaks.Errors[name] = p
+		}
+	}
+
+	for _, p := range ifaces {
+		peaks.Interfaces = append(peaks.Interfaces, *p)
+	}
+	sort.Slice(peaks.Interfaces, func(i, j int) bool {
+		return peaks.Interfaces[i].Interface < peaks.Interfaces[j].Interface
+	})
+	return peaks
+}
@@ -0,0 +1,50 @@
+package vpp
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+func TestSummarizePeaks(t *testing.T) {
+	now := time.Now()
+	sample := func(secs int, rx, drops, errs uint64) StatsSample {
+		return StatsSample{
+			Time: now.Add(time.Duration(secs) * time.Second),
+			Stats: &api.VppStats{
+				Interfaces: map[string]api.InterfaceStats{
+					"tap0": {Rx: &api.InterfaceCounter{Packets: rx}, Drops: drops},
+				},
+				Counters: map[string]uint64{"ip4-input/drop": errs},
+			},
+		}
+	}
+	samples := []StatsSample{
+		sample(0, 0, 0, 0),
+		sample(1, 10, 0, 1),
+		sample(2, 50, 5, 1),
+		sample(3, 60, 5, 4),
+	}
+	want := &StatsPeaks{
+		Start:   samples[0].Time,
+		End:     samples[3].Time,
+		Samples: 4,
+		Interfaces: []InterfacePeaks{
+			{
+				Interface: "tap0",
+				RxPps:     RatePeak{Rate: 40, Time: samples[2].Time},
+				DropRate:  RatePeak{Rate: 5, Time: samples[2].Time},
+			},
+		},
+		Errors: map[string]RatePeak{
+			"ip4-input/drop": {Rate: 3, Time: samples[3].Time},
+		},
+	}
+
+	got := SummarizePeaks(samples)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizePeaks() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,179 @@
+package vpp
+
+import (
+	"sort"
+	"time"
+
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+// StatsDumper is an interface for dumping VPP stats.
+type StatsDumper interface {
+	DumpStats() (*api.VppStats, error)
+}
+
+// StatsSample is a snapshot of VPP stats.
+type StatsSample struct {
+	Time  time.Time
+	Stats *api.VppStats
+}
+
+// StatsRates contains rates computed from two stats samples.
+type StatsRates struct {
+	Interval   time.Duration
+	Interfaces []InterfaceRates
+	Errors     map[string]float64 `json:",omitempty"`
+
+	// ErrorsPerWorker contains per-thread rates of error counters.
+	ErrorsPerWorker map[string][]float64 `json:",omitempty"`
+	// NodeVectorsPerWorker contains per-thread rates of vectors processed by nodes.
+	NodeVectorsPerWorker map[string][]float64 `json:",omitempty"`
+}
+
+// InterfaceRates contains per-second rates of interface counters.
+type InterfaceRates struct {
+	Interface string
+
+	RxPps float64
+	RxBps float64
+	TxPps float64
+	TxBps float64
+
+	DropRate    float64 `json:",omitempty"`
+	RxErrorRate float64 `json:",omitempty"`
+	TxErrorRate float64 `json:",omitempty"`
+	RxNoBufRate float64 `json:",omitempty"`
+	RxMissRate  float64 `json:",omitempty"`
+	PuntRate    float64 `json:",omitempty"`
+}
+
+// StatsSampler computes rates from consecutive stats samples.
+type StatsSampler struct {
+	dumper StatsDumper
+	prev   *StatsSample
+}
+
+// NewStatsSampler returns a new sampler for dumper.
+func NewStatsSampler(dumper StatsDumper) *StatsSampler {
+	return &StatsSampler{
+		dumper: dumper,
+	}
+}
+
+// Sample takes a new stats sample and returns rates computed since the previous
+// sample. It returns nil rates for the first sample.
+func (s *StatsSampler) Sample() (*StatsRates, error) {
+	stats, err := s.dumper.DumpStats()
+	if err != nil {
+		return nil, err
+	}
+	curr := &StatsSample{
+		Time:  time.Now(),
+		Stats: stats,
+	}
+	prev := s.prev
+	s.prev = curr
+	if prev == nil {
+		return nil, nil
+	}
+	return ComputeRates(*prev, *curr), nil
+}
+
+// ComputeRates computes per-second rates of interface and error counters
+// between two samples. Counters that decreased (e.g. were cleared) are
+// treated as unchanged.
+func ComputeRates(prev, curr StatsSample) *StatsRates {
+	interval := curr.Time.Sub(prev.Time)
+	rates := &StatsRates{
+		Interval: interval,
+	}
+	if interval <= 0 || prev.Stats == nil || curr.Stats == nil {
+		return rates
+	}
+	secs := interval.Seconds()
+	rate := func(prev, curr uint64) float64 {
+		if curr <= prev {
+			return 0
+		}
+		return float64(curr-prev) / secs
+	}
+
+	for name, c := range curr.Stats.Interfaces {
+		p := prev.Stats.Interfaces[name]
+		prx, ptx := counterOrZero(p.Rx), counterOrZero(p.Tx)
+		crx, ctx := counterOrZero(c.Rx), counterOrZero(c.Tx)
+		rates.Interfaces = append(rates.Interfaces, InterfaceRates{
+			Interface:   name,
+			RxPps:       rate(prx.Packets, crx.Packets),
+			RxBps:       rate(prx.Bytes, crx.Bytes) * 8,
+			TxPps:       rate(ptx.Packets, ctx.Packets),
+			TxBps:       rate(ptx.Bytes, ctx.Bytes) * 8,
+			DropRate:    rate(p.Drops, c.Drops),
+			RxErrorRate: rate(p.RxErrors, c.RxErrors),
+			TxErrorRate: rate(p.TxErrors, c.TxErrors),
+			RxNoBufRate: rate(p.RxNoBuf, c.RxNoBuf),
+			RxMissRate:  rate(p.RxMiss, c.RxMiss),
+			PuntRate:    rate(p.Punts, c.Punts),
+		})
+	}
+	sort.Slice(rates.Interfaces, func(i, j int) bool {
+		return rates.Interfaces[i].Interface < rates.Interfaces[j].Interface
+	})
+
+	for name, value := range curr.Stats.Counters {
+		if r := rate(prev.Stats.Counters[name], value); r > 0 {
+			if rates.Errors == nil {
+				rates.Errors = map[string]float64{}
+			}
+			rates.Errors[name] = r
+		}
+	}
+
+	for name, values := range curr.Stats.CountersPerWorker {
+		if _, ok := rates.Errors[name]; !ok {
+			continue
+		}
+		if rates.ErrorsPerWorker == nil {
+			rates.ErrorsPerWorker = map[string][]float64{}
+		}
+		prevValues := prev.Stats.CountersPerWorker[name]
+		perWorker := make([]float64, len(values))
+		for w, v := range values {
+			if w < len(prevValues) {
+				perWorker[w] = rate(prevValues[w], v)
+			} else {
+				perWorker[w] = rate(0, v)
+			}
+		}
+		rates.ErrorsPerWorker[name] = perWorker
+	}
+
+	for name, nodes := range curr.Stats.NodesPerWorker {
+		prevNodes := prev.Stats.NodesPerWorker[name]
+		perWorker := make([]float64, len(nodes))
+		var total float64
+		for w, n := range nodes {
+			var p uint64
+			if w < len(prevNodes) {
+				p = prevNodes[w].Vectors
+			}
+			perWorker[w] = rate(p, n.Vectors)
+			total += perWorker[w]
+		}
+		if total > 0 {
+			if rates.NodeVectorsPerWorker == nil {
+				rates.NodeVectorsPerWorker = map[string][]float64{}
+			}
+			rates.NodeVectorsPerWorker[name] = perWorker
+		}
+	}
+
+	return rates
+}
+
+func counterOrZero(c *api.InterfaceCounter) api.InterfaceCounter {
+	if c == nil {
+		return api.InterfaceCounter{}
+	}
+	return *c
+}
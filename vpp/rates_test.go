@@ -0,0 +1,75 @@
+package vpp
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+func TestComputeRates(t *testing.T) {
+	now := time.Now()
+	prev := StatsSample{
+		Time: now,
+		Stats: &api.VppStats{
+			Interfaces: map[string]api.InterfaceStats{
+				"tap0": {
+					Rx:    &api.InterfaceCounter{Packets: 100, Bytes: 10000},
+					Tx:    &api.InterfaceCounter{Packets: 50, Bytes: 5000},
+					Drops: 10,
+				},
+				"local0": {},
+			},
+			Counters: map[string]uint64{
+				"ip4-input/ip4 ttl <= 1": 5,
+				"cleared":                100,
+			},
+			CountersPerWorker: map[string][]uint64{
+				"ip4-input/ip4 ttl <= 1": {1, 4},
+				"cleared":                {100},
+			},
+		},
+	}
+	curr := StatsSample{
+		Time: now.Add(2 * time.Second),
+		Stats: &api.VppStats{
+			Interfaces: map[string]api.InterfaceStats{
+				"tap0": {
+					Rx:    &api.InterfaceCounter{Packets: 300, Bytes: 30000},
+					Tx:    &api.InterfaceCounter{Packets: 150, Bytes: 15000},
+					Drops: 14,
+				},
+				"local0":   {},
+				"memif0/0": {Rx: &api.InterfaceCounter{Packets: 20, Bytes: 2000}},
+			},
+			Counters: map[string]uint64{
+				"ip4-input/ip4 ttl <= 1": 9,
+				"cleared":                10,
+			},
+			CountersPerWorker: map[string][]uint64{
+				"ip4-input/ip4 ttl <= 1": {1, 8},
+				"cleared":                {10},
+			},
+		},
+	}
+	want := &StatsRates{
+		Interval: 2 * time.Second,
+		Interfaces: []InterfaceRates{
+			{Interface: "local0"},
+			{Interface: "memif0/0", RxPps: 10, RxBps: 8000},
+			{Interface: "tap0", RxPps: 100, RxBps: 80000, TxPps: 50, TxBps: 40000, DropRate: 2},
+		},
+		Errors: map[string]float64{
+			"ip4-input/ip4 ttl <= 1": 2,
+		},
+		ErrorsPerWorker: map[string][]float64{
+			"ip4-input/ip4 ttl <= 1": {0, 2},
+		},
+	}
+
+	got := ComputeRates(prev, curr)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ComputeRates() = %+v, want %+v", got, want)
+	}
+}
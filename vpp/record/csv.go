@@ -0,0 +1,121 @@
+package record
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var csvHeader = []string{"time", "measurement", "tags", "field", "value"}
+
+// csvWriter writes records as points with a row per field. Tags are encoded
+// as URL query.
+type csvWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (w *csvWriter) Write(rec *Record) error {
+	if !w.header {
+		if err := w.w.Write(csvHeader); err != nil {
+			return err
+		}
+		w.header = true
+	}
+	for _, p := range Flatten(rec) {
+		tags := url.Values{}
+		for k, v := range p.Tags {
+			tags.Set(k, v)
+		}
+		fields := make([]string, 0, len(p.Fields))
+		for k := range p.Fields {
+			fields = append(fields, k)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			row := []string{
+				p.Time.Format(time.RFC3339Nano),
+				p.Measurement,
+				tags.Encode(),
+				field,
+				strconv.FormatUint(p.Fields[field], 10),
+			}
+			if err := w.w.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func readCSV(r io.Reader) ([]Point, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(csvHeader)
+
+	var points []Point
+	for line := 1; ; line++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if line == 1 && row[0] == csvHeader[0] {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339Nano, row[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid time: %w", line, err)
+		}
+		value, err := strconv.ParseUint(row[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid value: %w", line, err)
+		}
+
+		// consecutive rows of the same point are merged
+		if n := len(points); n > 0 && sameSeries(points[n-1], t, row[1], row[2]) {
+			points[n-1].Fields[row[3]] = value
+			continue
+		}
+		query, err := url.ParseQuery(row[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid tags: %w", line, err)
+		}
+		tags := make(map[string]string, len(query))
+		for k := range query {
+			tags[k] = query.Get(k)
+		}
+		points = append(points, Point{
+			Time:        t,
+			Measurement: row[1],
+			Tags:        tags,
+			Fields:      map[string]uint64{row[3]: value},
+		})
+	}
+	return points, nil
+}
+
+func sameSeries(p Point, t time.Time, measurement, tags string) bool {
+	if !p.Time.Equal(t) || p.Measurement != measurement {
+		return false
+	}
+	values := url.Values{}
+	for k, v := range p.Tags {
+		values.Set(k, v)
+	}
+	return values.Encode() == tags
+}
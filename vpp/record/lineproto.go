@@ -0,0 +1,164 @@
+package record
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// lineProtoWriter writes records as points in InfluxDB line protocol.
+type lineProtoWriter struct {
+	w *bufio.Writer
+}
+
+func (w *lineProtoWriter) Write(rec *Record) error {
+	for _, p := range Flatten(rec) {
+		if _, err := w.w.WriteString(formatLineProto(p)); err != nil {
+			return err
+		}
+		if err := w.w.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *lineProtoWriter) Flush() error {
+	return w.w.Flush()
+}
+
+var (
+	measurementEscaper = strings.NewReplacer(`,`, `\,`, ` `, `\ `)
+	tagEscaper         = strings.NewReplacer(`,`, `\,`, ` `, `\ `, `=`, `\=`)
+)
+
+func formatLineProto(p Point) string {
+	var b strings.Builder
+	b.WriteString(measurementEscaper.Replace(p.Measurement))
+
+	tags := make([]string, 0, len(p.Tags))
+	for k := range p.Tags {
+		tags = append(tags, k)
+	}
+	sort.Strings(tags)
+	for _, k := range tags {
+		if p.Tags[k] == "" {
+			continue
+		}
+		fmt.Fprintf(&b, ",%s=%s", tagEscaper.Replace(k), tagEscaper.Replace(p.Tags[k]))
+	}
+
+	fields := make([]string, 0, len(p.Fields))
+	for k := range p.Fields {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+	for i, k := range fields {
+		sep := ","
+		if i == 0 {
+			sep = " "
+		}
+		fmt.Fprintf(&b, "%s%s=%di", sep, tagEscaper.Replace(k), p.Fields[k])
+	}
+
+	fmt.Fprintf(&b, " %d", p.Time.UnixNano())
+	return b.String()
+}
+
+func readLineProto(r io.Reader) ([]Point, error) {
+	var points []Point
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var n int
+	for scanner.Scan() {
+		n++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p, err := parseLineProto(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		points = append(points, p)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+func parseLineProto(line string) (Point, error) {
+	parts := splitUnescaped(line, ' ')
+	if len(parts) != 3 {
+		return Point{}, fmt.Errorf("expected measurement, fields and timestamp, got %d parts", len(parts))
+	}
+
+	p := Point{
+		Tags:   map[string]string{},
+		Fields: map[string]uint64{},
+	}
+	series := splitUnescaped(parts[0], ',')
+	p.Measurement = unescape(series[0])
+	for _, tag := range series[1:] {
+		kv := splitUnescaped(tag, '=')
+		if len(kv) != 2 {
+			return Point{}, fmt.Errorf("invalid tag: %q", tag)
+		}
+		p.Tags[unescape(kv[0])] = unescape(kv[1])
+	}
+
+	for _, field := range splitUnescaped(parts[1], ',') {
+		kv := splitUnescaped(field, '=')
+		if len(kv) != 2 {
+			return Point{}, fmt.Errorf("invalid field: %q", field)
+		}
+		v, err := strconv.ParseUint(strings.TrimSuffix(kv[1], "i"), 10, 64)
+		if err != nil {
+			return Point{}, fmt.Errorf("invalid value of field %q: %w", kv[0], err)
+		}
+		p.Fields[unescape(kv[0])] = v
+	}
+
+	ts, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid timestamp: %w", err)
+	}
+	p.Time = time.Unix(0, ts)
+
+	return p, nil
+}
+
+// splitUnescaped splits s by sep not preceded by backslash.
+func splitUnescaped(s string, sep byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
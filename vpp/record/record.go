@@ -0,0 +1,314 @@
+// Package record handles recording of VPP stats samples to files.
+package record
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	govppapi "go.fd.io/govpp/api"
+
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+// Format is a format of recording.
+type Format string
+
+const (
+	FormatJSON      Format = "json"
+	FormatLineProto Format = "lp"
+	FormatCSV       Format = "csv"
+)
+
+// DetectFormat returns format based on extension of filename, defaults to JSON.
+func DetectFormat(filename string) Format {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".lp", ".influx", ".line":
+		return FormatLineProto
+	case ".csv":
+		return FormatCSV
+	default:
+		return FormatJSON
+	}
+}
+
+// Record is a stats sample of an instance.
+type Record struct {
+	Time     time.Time
+	Instance string
+	Tags     map[string]string `json:",omitempty"`
+	Stats    *api.VppStats
+}
+
+// Writer writes records to recording.
+type Writer interface {
+	Write(*Record) error
+	Flush() error
+}
+
+// NewWriter returns writer for format.
+func NewWriter(w io.Writer, format Format) (Writer, error) {
+	switch format {
+	case FormatJSON, "":
+		return &jsonWriter{w: bufio.NewWriter(w)}, nil
+	case FormatLineProto:
+		return &lineProtoWriter{w: bufio.NewWriter(w)}, nil
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unknown format: %q", format)
+	}
+}
+
+// ReadRecords reads all records from recording in format.
+func ReadRecords(r io.Reader, format Format) ([]*Record, error) {
+	switch format {
+	case FormatJSON, "":
+		return readJSON(r)
+	case FormatLineProto:
+		points, err := readLineProto(r)
+		if err != nil {
+			return nil, err
+		}
+		return Unflatten(points), nil
+	case FormatCSV:
+		points, err := readCSV(r)
+		if err != nil {
+			return nil, err
+		}
+		return Unflatten(points), nil
+	default:
+		return nil, fmt.Errorf("unknown format: %q", format)
+	}
+}
+
+type jsonWriter struct {
+	w *bufio.Writer
+}
+
+func (w *jsonWriter) Write(rec *Record) error {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	w.w.Write(b)
+	return w.w.WriteByte('\n')
+}
+
+func (w *jsonWriter) Flush() error {
+	return w.w.Flush()
+}
+
+func readJSON(r io.Reader) ([]*Record, error) {
+	var records []*Record
+	dec := json.NewDecoder(r)
+	for {
+		var rec Record
+		if err := dec.Decode(&rec); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("decoding record %d failed: %w", len(records)+1, err)
+		}
+		records = append(records, &rec)
+	}
+	return records, nil
+}
+
+// Measurements of points.
+const (
+	MeasurementSystem    = "vpp_system"
+	MeasurementInterface = "vpp_interface"
+	MeasurementError     = "vpp_error"
+	MeasurementNode      = "vpp_node"
+)
+
+// Tags identifying instance and object of points.
+const (
+	TagInstance  = "instance"
+	TagInterface = "interface"
+	TagCounter   = "counter"
+	TagNode      = "vpp_node"
+)
+
+// Point is a single measurement with tags and fields.
+type Point struct {
+	Time        time.Time
+	Measurement string
+	Tags        map[string]string
+	Fields      map[string]uint64
+}
+
+// Flatten converts record to points.
+func Flatten(rec *Record) []Point {
+	if rec.Stats == nil {
+		return nil
+	}
+	tags := func(kv ...string) map[string]string {
+		t := make(map[string]string, len(rec.Tags)+1+len(kv)/2)
+		for k, v := range rec.Tags {
+			t[k] = v
+		}
+		t[TagInstance] = rec.Instance
+		for i := 0; i+1 < len(kv); i += 2 {
+			t[kv[i]] = kv[i+1]
+		}
+		return t
+	}
+	stats := rec.Stats
+
+	points := []Point{{
+		Time:        rec.Time,
+		Measurement: MeasurementSystem,
+		Tags:        tags(),
+		Fields: map[string]uint64{
+			"vector_rate":        stats.System.VectorRate,
+			"input_rate":         stats.System.InputRate,
+			"num_worker_threads": stats.System.NumWorkerThreads,
+			"heartbeat":          stats.System.Heartbeat,
+		},
+	}}
+
+	ifaceNames := make([]string, 0, len(stats.Interfaces))
+	for name := range stats.Interfaces {
+		ifaceNames = append(ifaceNames, name)
+	}
+	sort.Strings(ifaceNames)
+	for _, name := range ifaceNames {
+		c := stats.Interfaces[name]
+		fields := map[string]uint64{
+			"drops":     c.Drops,
+			"punts":     c.Punts,
+			"rx_errors": c.RxErrors,
+			"tx_errors": c.TxErrors,
+			"rx_no_buf": c.RxNoBuf,
+			"rx_miss":   c.RxMiss,
+		}
+		if c.Rx != nil {
+			fields["rx_packets"] = c.Rx.Packets
+			fields["rx_bytes"] = c.Rx.Bytes
+		}
+		if c.Tx != nil {
+			fields["tx_packets"] = c.Tx.Packets
+			fields["tx_bytes"] = c.Tx.Bytes
+		}
+		points = append(points, Point{
+			Time:        rec.Time,
+			Measurement: MeasurementInterface,
+			Tags:        tags(TagInterface, name),
+			Fields:      fields,
+		})
+	}
+
+	counterNames := make([]string, 0, len(stats.Counters))
+	for name := range stats.Counters {
+		counterNames = append(counterNames, name)
+	}
+	sort.Strings(counterNames)
+	for _, name := range counterNames {
+		points = append(points, Point{
+			Time:        rec.Time,
+			Measurement: MeasurementError,
+			Tags:        tags(TagCounter, name),
+			Fields:      map[string]uint64{"value": stats.Counters[name]},
+		})
+	}
+
+	for _, n := range stats.Nodes {
+		if n.Calls == 0 {
+			continue
+		}
+		points = append(points, Point{
+			Time:        rec.Time,
+			Measurement: MeasurementNode,
+			Tags:        tags(TagNode, n.NodeName),
+			Fields: map[string]uint64{
+				"clocks":   n.Clocks,
+				"vectors":  n.Vectors,
+				"calls":    n.Calls,
+				"suspends": n.Suspends,
+			},
+		})
+	}
+
+	return points
+}
+
+// Unflatten converts points to records, grouping them by time and instance
+// in order of appearance.
+func Unflatten(points []Point) []*Record {
+	type key struct {
+		time     int64
+		instance string
+	}
+	var records []*Record
+	index := map[key]*Record{}
+
+	for _, p := range points {
+		k := key{p.Time.UnixNano(), p.Tags[TagInstance]}
+		rec, ok := index[k]
+		if !ok {
+			rec = &Record{
+				Time:     p.Time,
+				Instance: k.instance,
+				Stats: &api.VppStats{
+					Interfaces: map[string]api.InterfaceStats{},
+					Counters:   map[string]uint64{},
+				},
+			}
+			for t, v := range p.Tags {
+				switch t {
+				case TagInstance, TagInterface, TagCounter, TagNode:
+				default:
+					if rec.Tags == nil {
+						rec.Tags = map[string]string{}
+					}
+					rec.Tags[t] = v
+				}
+			}
+			index[k] = rec
+			records = append(records, rec)
+		}
+		stats := rec.Stats
+		f := p.Fields
+
+		switch p.Measurement {
+		case MeasurementSystem:
+			stats.System.VectorRate = f["vector_rate"]
+			stats.System.InputRate = f["input_rate"]
+			stats.System.NumWorkerThreads = f["num_worker_threads"]
+			stats.System.Heartbeat = f["heartbeat"]
+		case MeasurementInterface:
+			c := api.InterfaceStats{
+				Drops:    f["drops"],
+				Punts:    f["punts"],
+				RxErrors: f["rx_errors"],
+				TxErrors: f["tx_errors"],
+				RxNoBuf:  f["rx_no_buf"],
+				RxMiss:   f["rx_miss"],
+			}
+			if _, ok := f["rx_packets"]; ok {
+				c.Rx = &api.InterfaceCounter{Packets: f["rx_packets"], Bytes: f["rx_bytes"]}
+			}
+			if _, ok := f["tx_packets"]; ok {
+				c.Tx = &api.InterfaceCounter{Packets: f["tx_packets"], Bytes: f["tx_bytes"]}
+			}
+			stats.Interfaces[p.Tags[TagInterface]] = c
+		case MeasurementError:
+			stats.Counters[p.Tags[TagCounter]] = f["value"]
+		case MeasurementNode:
+			stats.Nodes = append(stats.Nodes, govppapi.NodeCounters{
+				NodeName: p.Tags[TagNode],
+				Clocks:   f["clocks"],
+				Vectors:  f["vectors"],
+				Calls:    f["calls"],
+				Suspends: f["suspends"],
+			})
+		}
+	}
+	return records
+}
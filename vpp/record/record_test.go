@@ -0,0 +1,99 @@
+package record
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	govppapi "go.fd.io/govpp/api"
+
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+func TestWriteReadRecords(t *testing.T) {
+	now := time.Unix(1700000000, 123000000).UTC()
+	records := []*Record{
+		{
+			Time:     now,
+			Instance: "vpp1",
+			Tags:     map[string]string{"namespace": "default", "pod": "vpp, 1"},
+			Stats: &api.VppStats{
+				System: govppapi.SystemStats{VectorRate: 2, NumWorkerThreads: 1},
+				Nodes: []govppapi.NodeCounters{
+					{NodeName: "ip4-input", Calls: 10, Vectors: 20, Clocks: 300},
+				},
+				Interfaces: map[string]api.InterfaceStats{
+					"tap0":   {Rx: &api.InterfaceCounter{Packets: 10, Bytes: 1000}, Drops: 1},
+					"local0": {},
+				},
+				Counters: map[string]uint64{
+					"ip4-input/ip4 ttl <= 1": 5,
+				},
+			},
+		},
+		{
+			Time:     now.Add(5 * time.Second),
+			Instance: "vpp1",
+			Tags:     map[string]string{"namespace": "default", "pod": "vpp, 1"},
+			Stats: &api.VppStats{
+				Interfaces: map[string]api.InterfaceStats{
+					"tap0": {Tx: &api.InterfaceCounter{Packets: 3, Bytes: 300}},
+				},
+				Counters: map[string]uint64{},
+			},
+		},
+	}
+
+	for _, format := range []Format{FormatJSON, FormatLineProto, FormatCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(&buf, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, rec := range records {
+				if err := w.Write(rec); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ReadRecords(&buf, format)
+			if err != nil {
+				t.Fatalf("ReadRecords() error: %v\n%s", err, buf.String())
+			}
+			if len(got) != len(records) {
+				t.Fatalf("ReadRecords() returned %d records, want %d", len(got), len(records))
+			}
+			for i, rec := range got {
+				want := records[i]
+				if !rec.Time.Equal(want.Time) || rec.Instance != want.Instance || !reflect.DeepEqual(rec.Tags, want.Tags) {
+					t.Errorf("record %d = %v %v %v, want %v %v %v", i, rec.Time, rec.Instance, rec.Tags, want.Time, want.Instance, want.Tags)
+				}
+				if !reflect.DeepEqual(rec.Stats, want.Stats) {
+					t.Errorf("record %d stats = %+v, want %+v", i, rec.Stats, want.Stats)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     Format
+	}{
+		{"stats.lp", FormatLineProto},
+		{"stats.CSV", FormatCSV},
+		{"stats.jsonl", FormatJSON},
+		{"stats", FormatJSON},
+	}
+	for _, tt := range tests {
+		if got := DetectFormat(tt.filename); got != tt.want {
+			t.Errorf("DetectFormat(%q) = %v, want %v", tt.filename, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package vpp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"go.ligato.io/vpp-probe/probe"
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+const (
+	// DefaultCoreDir is the default directory where core files are searched for.
+	DefaultCoreDir = "/var/crash"
+	// DefaultPostMortemLogLines is the default number of log lines collected
+	// from the previous run.
+	DefaultPostMortemLogLines = 50
+
+	defaultVppLogFile = "/var/log/vpp/vpp.log"
+
+	// startedAtTolerance is max difference of computed start times
+	// that is not considered as restart (uptime has 1s resolution).
+	startedAtTolerance = time.Second * 5
+)
+
+// RunState identifies a single run of VPP process.
+type RunState struct {
+	Pid               int
+	Uptime            api.Uptime `json:",omitempty"`
+	ContainerRestarts int        `json:",omitempty"`
+	CheckedAt         time.Time
+}
+
+// StartedAt returns the approximate time when VPP was started.
+func (s RunState) StartedAt() time.Time {
+	return s.CheckedAt.Add(-time.Duration(s.Uptime) * time.Second)
+}
+
+// Restart describes a detected restart of VPP.
+type Restart struct {
+	Reason     string
+	Previous   RunState
+	Current    RunState
+	PostMortem *PostMortem `json:",omitempty"`
+}
+
+// PostMortem contains data collected after a restart was detected.
+type PostMortem struct {
+	Logs      []string              `json:",omitempty"`
+	CoreFiles []string              `json:",omitempty"`
+	Container *probe.ContainerState `json:",omitempty"`
+	Errors    []string              `json:",omitempty"`
+}
+
+// DetectRestart compares two run states and returns restart if the current
+// state belongs to a different run of VPP, otherwise it returns nil.
+func DetectRestart(prev, curr RunState) *Restart {
+	if prev.Pid == 0 || curr.Pid == 0 {
+		return nil
+	}
+	// uptime of 0 means it could not be read
+	uptimeKnown := prev.Uptime > 0 && curr.Uptime > 0
+	var reason string
+	switch {
+	case prev.Pid != curr.Pid:
+		reason = fmt.Sprintf("pid changed (%d -> %d)", prev.Pid, curr.Pid)
+	case curr.ContainerRestarts > prev.ContainerRestarts:
+		reason = fmt.Sprintf("container restarted %d times", curr.ContainerRestarts-prev.ContainerRestarts)
+	case uptimeKnown && curr.Uptime < prev.Uptime:
+		reason = fmt.Sprintf("uptime reset (%v -> %v)", prev.Uptime, curr.Uptime)
+	case uptimeKnown && curr.StartedAt().Sub(prev.StartedAt()) > startedAtTolerance:
+		reason = fmt.Sprintf("start time changed (%v -> %v)",
+			prev.StartedAt().Format(time.RFC3339), curr.StartedAt().Format(time.RFC3339))
+	default:
+		return nil
+	}
+	return &Restart{
+		Reason:   reason,
+		Previous: prev,
+		Current:  curr,
+	}
+}
+
+// CheckRunState retrieves current runtime info and returns run state of the instance.
+func (v *Instance) CheckRunState() (RunState, error) {
+	sysInfo, err := v.GetSystemInfo()
+	if err != nil {
+		return RunState{}, err
+	}
+	v.vppInfo.Runtime = *sysInfo
+
+	state := RunState{
+		Pid:       sysInfo.Pid,
+		Uptime:    sysInfo.Uptime,
+		CheckedAt: time.Now(),
+	}
+	if inspector, ok := v.handler.(probe.ContainerInspector); ok {
+		if cs, err := inspector.ContainerState(); err != nil {
+			logrus.Debugf("getting container state failed: %v", err)
+		} else {
+			state.ContainerRestarts = cs.RestartCount
+		}
+	}
+	return state, nil
+}
+
+// CollectPostMortem collects data useful for investigating cause of a restart:
+// tail of the log from previous run, list of core files in coreDir and
+// state of the container.
+func (v *Instance) CollectPostMortem(coreDir string, logLines int) *PostMortem {
+	pm := &PostMortem{}
+
+	inspector, isContainer := v.handler.(probe.ContainerInspector)
+	if isContainer {
+		if cs, err := inspector.ContainerState(); err != nil {
+			pm.Errors = append(pm.Errors, fmt.Sprintf("container state: %v", err))
+		} else {
+			pm.Container = cs
+		}
+		if logs, err := inspector.PreviousLogs(logLines); err != nil {
+			pm.Errors = append(pm.Errors, fmt.Sprintf("previous logs: %v", err))
+		} else {
+			pm.Logs = logs
+		}
+	}
+	if pm.Logs == nil {
+		out, err := v.handler.Command("tail", "-n", strconv.Itoa(logLines), defaultVppLogFile).Output()
+		if err != nil {
+			pm.Errors = append(pm.Errors, fmt.Sprintf("log file: %v", err))
+		} else if s := strings.TrimRight(string(out), "\n"); s != "" {
+			pm.Logs = strings.Split(s, "\n")
+		}
+	}
+
+	out, err := v.handler.Command("ls", "-1t", coreDir).Output()
+	if err != nil {
+		pm.Errors = append(pm.Errors, fmt.Sprintf("core files: %v", err))
+	} else {
+		for _, f := range strings.Fields(string(out)) {
+			pm.CoreFiles = append(pm.CoreFiles, filepath.Join(coreDir, f))
+		}
+	}
+
+	return pm
+}
+
+// DefaultRunStatesFile returns path to the file where run states are stored.
+func DefaultRunStatesFile() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "vpp-probe", "runstates.json")
+}
+
+// LoadRunStates loads run states of instances from file. It returns empty
+// map if the file does not exist.
+func LoadRunStates(file string) (map[string]RunState, error) {
+	states := map[string]RunState{}
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return states, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &states); err != nil {
+		return nil, fmt.Errorf("decoding run states from %s failed: %w", file, err)
+	}
+	return states, nil
+}
+
+// SaveRunStates saves run states of instances to file.
+func SaveRunStates(file string, states map[string]RunState) error {
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0644)
+}
@@ -0,0 +1,97 @@
+package vpp
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDetectRestart(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name    string
+		prev    RunState
+		curr    RunState
+		restart bool
+	}{
+		{
+			name:    "no previous state",
+			curr:    RunState{Pid: 10, Uptime: 100, CheckedAt: now},
+			restart: false,
+		},
+		{
+			name:    "same run",
+			prev:    RunState{Pid: 10, Uptime: 100, CheckedAt: now.Add(-time.Minute)},
+			curr:    RunState{Pid: 10, Uptime: 160, CheckedAt: now},
+			restart: false,
+		},
+		{
+			name:    "pid changed",
+			prev:    RunState{Pid: 10, Uptime: 100, CheckedAt: now.Add(-time.Minute)},
+			curr:    RunState{Pid: 20, Uptime: 160, CheckedAt: now},
+			restart: true,
+		},
+		{
+			name:    "uptime reset",
+			prev:    RunState{Pid: 1, Uptime: 100, CheckedAt: now.Add(-time.Minute)},
+			curr:    RunState{Pid: 1, Uptime: 10, CheckedAt: now},
+			restart: true,
+		},
+		{
+			name:    "unknown uptime",
+			prev:    RunState{Pid: 1, Uptime: 100, CheckedAt: now.Add(-time.Minute)},
+			curr:    RunState{Pid: 1, Uptime: 0, CheckedAt: now},
+			restart: false,
+		},
+		{
+			name:    "start time changed",
+			prev:    RunState{Pid: 1, Uptime: 100, CheckedAt: now.Add(-time.Hour)},
+			curr:    RunState{Pid: 1, Uptime: 200, CheckedAt: now},
+			restart: true,
+		},
+		{
+			name:    "container restarted",
+			prev:    RunState{Pid: 1, Uptime: 100, ContainerRestarts: 1, CheckedAt: now.Add(-time.Minute)},
+			curr:    RunState{Pid: 1, Uptime: 160, ContainerRestarts: 2, CheckedAt: now},
+			restart: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectRestart(tt.prev, tt.curr)
+			if (got != nil) != tt.restart {
+				t.Errorf("DetectRestart() = %+v, want restart %v", got, tt.restart)
+			}
+		})
+	}
+}
+
+func TestRunStatesFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "states", "runstates.json")
+
+	states, err := LoadRunStates(file)
+	if err != nil {
+		t.Fatalf("LoadRunStates() error = %v", err)
+	}
+	if len(states) != 0 {
+		t.Fatalf("expected no states, got %+v", states)
+	}
+
+	states["instance::test"] = RunState{
+		Pid:       123,
+		Uptime:    3600,
+		CheckedAt: time.Now().Truncate(time.Second).UTC(),
+	}
+	if err := SaveRunStates(file, states); err != nil {
+		t.Fatalf("SaveRunStates() error = %v", err)
+	}
+	loaded, err := LoadRunStates(file)
+	if err != nil {
+		t.Fatalf("LoadRunStates() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded, states) {
+		t.Errorf("loaded states = %+v, want %+v", loaded, states)
+	}
+}
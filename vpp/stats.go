@@ -93,6 +93,7 @@ func DumpStats(stats govppapi.StatsProvider) (*api.VppStats, error) {
 		return nil, err
 	}
 	counters := map[string]uint64{}
+	countersPerWorker := map[string][]uint64{}
 	for _, c := range errstats.Errors {
 		var value uint64
 		for _, val := range c.Values {
@@ -100,6 +101,7 @@ func DumpStats(stats govppapi.StatsProvider) (*api.VppStats, error) {
 		}
 		if value > 0 {
 			counters[c.CounterName] = value
+			countersPerWorker[c.CounterName] = c.Values
 		}
 	}
 
@@ -107,13 +109,98 @@ func DumpStats(stats govppapi.StatsProvider) (*api.VppStats, error) {
 		System: sys,
 		Nodes:  nodestats.Nodes,
 		//NodeStats:  nodestats,
-		Interfaces: interfaces,
-		Counters:   counters,
+		Interfaces:        interfaces,
+		Counters:          counters,
+		CountersPerWorker: countersPerWorker,
+	}
+
+	if dir, ok := stats.(StatsDirectory); ok {
+		nodes, err := dumpNodesPerWorker(dir)
+		if err != nil {
+			logrus.Debugf("dumping per-worker node stats failed: %v", err)
+		} else {
+			s.NodesPerWorker = nodes
+		}
 	}
 
 	return s, nil
 }
 
+// dumpNodesPerWorker returns per-thread counters of nodes with calls.
+func dumpNodesPerWorker(dir StatsDirectory) (map[string][]govppapi.NodeCounters, error) {
+	list, err := dir.DumpStats(`^/sys/node/`)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]api.StatEntry, len(list))
+	for i, e := range list {
+		entries[i] = toStatEntry(e)
+	}
+	return NodesPerWorker(entries), nil
+}
+
+// NodesPerWorker builds per-thread node counters from /sys/node/* entries.
+func NodesPerWorker(entries []api.StatEntry) map[string][]govppapi.NodeCounters {
+	var names []string
+	for _, e := range entries {
+		if e.Name == "/sys/node/names" {
+			names = e.Names
+		}
+	}
+	var workers int
+	for _, e := range entries {
+		if len(e.Counters) > workers {
+			workers = len(e.Counters)
+		}
+	}
+
+	perWorker := make([][]govppapi.NodeCounters, workers)
+	for w := range perWorker {
+		perWorker[w] = make([]govppapi.NodeCounters, len(names))
+	}
+	for _, e := range entries {
+		var set func(n *govppapi.NodeCounters, v uint64)
+		switch e.Name {
+		case "/sys/node/clocks":
+			set = func(n *govppapi.NodeCounters, v uint64) { n.Clocks = v }
+		case "/sys/node/vectors":
+			set = func(n *govppapi.NodeCounters, v uint64) { n.Vectors = v }
+		case "/sys/node/calls":
+			set = func(n *govppapi.NodeCounters, v uint64) { n.Calls = v }
+		case "/sys/node/suspends":
+			set = func(n *govppapi.NodeCounters, v uint64) { n.Suspends = v }
+		default:
+			continue
+		}
+		for w, values := range e.Counters {
+			for i, v := range values {
+				if i < len(names) {
+					set(&perWorker[w][i], v)
+				}
+			}
+		}
+	}
+
+	nodes := map[string][]govppapi.NodeCounters{}
+	for i, name := range names {
+		if name == "" {
+			continue
+		}
+		var calls uint64
+		counters := make([]govppapi.NodeCounters, workers)
+		for w := range perWorker {
+			counters[w] = perWorker[w][i]
+			counters[w].NodeIndex = uint32(i)
+			counters[w].NodeName = name
+			calls += counters[w].Calls
+		}
+		if calls > 0 {
+			nodes[name] = counters
+		}
+	}
+	return nodes
+}
+
 func toCombined(cc govppapi.InterfaceCounterCombined) *api.InterfaceCounter {
 	if cc.Bytes == 0 && cc.Packets == 0 {
 		return nil
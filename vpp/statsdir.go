@@ -0,0 +1,291 @@
+package vpp
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.fd.io/govpp/adapter"
+	govppapi "go.fd.io/govpp/api"
+
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+// StatsDirectory is implemented by stats providers with access to stats
+// segment directory, e.g. local stats client.
+type StatsDirectory interface {
+	DumpStats(patterns ...string) ([]adapter.StatEntry, error)
+}
+
+// GetStats returns stats segment entries with names matching any of the glob
+// patterns, where * matches any sequence of characters. Providers without
+// directory access (e.g. govpp proxy) are served from typed stats, which
+// contain only known paths and have node and interface counters summed
+// across workers, such entries are marked as aggregated.
+func GetStats(stats govppapi.StatsProvider, patterns ...string) ([]api.StatEntry, error) {
+	regexps := make([]*regexp.Regexp, len(patterns))
+	for i, pattern := range patterns {
+		re, err := globToRegexp(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		regexps[i] = re
+	}
+
+	var entries []api.StatEntry
+	if dir, ok := stats.(StatsDirectory); ok {
+		exprs := make([]string, len(regexps))
+		for i, re := range regexps {
+			exprs[i] = re.String()
+		}
+		list, err := dir.DumpStats(exprs...)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range list {
+			entries = append(entries, toStatEntry(e))
+		}
+	} else {
+		list, err := typedStatEntries(stats)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range list {
+			if matchAny(regexps, e.Name) {
+				entries = append(entries, e)
+			}
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+func (v *Instance) GetStats(patterns ...string) ([]api.StatEntry, error) {
+	if v.stats == nil {
+		return nil, ErrStatsUnavailable
+	}
+	return GetStats(v.stats, patterns...)
+}
+
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteByte('^')
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteByte('.')
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteByte('$')
+	return regexp.Compile(b.String())
+}
+
+func matchAny(regexps []*regexp.Regexp, name string) bool {
+	if len(regexps) == 0 {
+		return true
+	}
+	for _, re := range regexps {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func toStatEntry(e adapter.StatEntry) api.StatEntry {
+	entry := api.StatEntry{
+		Name:    string(e.Name),
+		Symlink: e.Symlink,
+	}
+	switch data := e.Data.(type) {
+	case adapter.ScalarStat:
+		v := float64(data)
+		entry.Type = api.StatScalar
+		entry.Scalar = &v
+	case adapter.ErrorStat:
+		entry.Type = api.StatError
+		entry.Errors = make([]uint64, len(data))
+		for i, c := range data {
+			entry.Errors[i] = uint64(c)
+		}
+	case adapter.SimpleCounterStat:
+		entry.Type = api.StatCounter
+		entry.Counters = make([][]uint64, len(data))
+		for w, counters := range data {
+			entry.Counters[w] = make([]uint64, len(counters))
+			for i, c := range counters {
+				entry.Counters[w][i] = uint64(c)
+			}
+		}
+	case adapter.CombinedCounterStat:
+		entry.Type = api.StatCombined
+		entry.Combined = make([][]api.CombinedCounter, len(data))
+		for w, counters := range data {
+			entry.Combined[w] = make([]api.CombinedCounter, len(counters))
+			for i, c := range counters {
+				entry.Combined[w][i] = api.CombinedCounter{Packets: c.Packets(), Bytes: c.Bytes()}
+			}
+		}
+	case adapter.NameStat:
+		entry.Type = api.StatName
+		entry.Names = make([]string, len(data))
+		for i, n := range data {
+			entry.Names[i] = n.String()
+		}
+	default:
+		entry.Type = api.StatEmpty
+	}
+	return entry
+}
+
+// typedStatEntries builds stats segment entries from typed stats.
+func typedStatEntries(stats govppapi.StatsProvider) ([]api.StatEntry, error) {
+	var sys govppapi.SystemStats
+	if err := stats.GetSystemStats(&sys); err != nil {
+		return nil, err
+	}
+	var nodes govppapi.NodeStats
+	if err := stats.GetNodeStats(&nodes); err != nil {
+		return nil, err
+	}
+	var ifaces govppapi.InterfaceStats
+	if err := stats.GetInterfaceStats(&ifaces); err != nil {
+		return nil, err
+	}
+	var errs govppapi.ErrorStats
+	if err := stats.GetErrorStats(&errs); err != nil {
+		return nil, err
+	}
+	var bufs govppapi.BufferStats
+	if err := stats.GetBufferStats(&bufs); err != nil {
+		return nil, err
+	}
+
+	var entries []api.StatEntry
+	scalar := func(name string, v float64) {
+		entries = append(entries, api.StatEntry{Name: name, Type: api.StatScalar, Scalar: &v})
+	}
+
+	scalar("/sys/vector_rate", float64(sys.VectorRate))
+	scalar("/sys/num_worker_threads", float64(sys.NumWorkerThreads))
+	scalar("/sys/input_rate", float64(sys.InputRate))
+	scalar("/sys/last_update", float64(sys.LastUpdate))
+	scalar("/sys/last_stats_clear", float64(sys.LastStatsClear))
+	scalar("/sys/heartbeat", float64(sys.Heartbeat))
+	perWorker := make([][]uint64, len(sys.VectorRatePerWorker))
+	for w, v := range sys.VectorRatePerWorker {
+		perWorker[w] = []uint64{v}
+	}
+	entries = append(entries, api.StatEntry{Name: "/sys/vector_rate_per_worker", Type: api.StatCounter, Counters: perWorker})
+
+	nodeNames := make([]string, len(nodes.Nodes))
+	for i, n := range nodes.Nodes {
+		nodeNames[i] = n.NodeName
+	}
+	entries = append(entries, api.StatEntry{Name: "/sys/node/names", Type: api.StatName, Names: nodeNames})
+	nodeCounters := []struct {
+		name  string
+		value func(govppapi.NodeCounters) uint64
+	}{
+		{"clocks", func(n govppapi.NodeCounters) uint64 { return n.Clocks }},
+		{"vectors", func(n govppapi.NodeCounters) uint64 { return n.Vectors }},
+		{"calls", func(n govppapi.NodeCounters) uint64 { return n.Calls }},
+		{"suspends", func(n govppapi.NodeCounters) uint64 { return n.Suspends }},
+	}
+	for _, c := range nodeCounters {
+		values := make([]uint64, len(nodes.Nodes))
+		for i, n := range nodes.Nodes {
+			values[i] = c.value(n)
+			entries = append(entries, api.StatEntry{
+				Name:       "/nodes/" + n.NodeName + "/" + c.name,
+				Type:       api.StatCounter,
+				Symlink:    true,
+				Counters:   [][]uint64{{values[i]}},
+				Aggregated: true,
+			})
+		}
+		entries = append(entries, api.StatEntry{Name: "/sys/node/" + c.name, Type: api.StatCounter, Counters: [][]uint64{values}, Aggregated: true})
+	}
+
+	ifaceNames := make([]string, len(ifaces.Interfaces))
+	for i, iface := range ifaces.Interfaces {
+		ifaceNames[i] = iface.InterfaceName
+	}
+	entries = append(entries, api.StatEntry{Name: "/if/names", Type: api.StatName, Names: ifaceNames})
+	ifaceSimple := []struct {
+		name  string
+		value func(govppapi.InterfaceCounters) uint64
+	}{
+		{"drops", func(c govppapi.InterfaceCounters) uint64 { return c.Drops }},
+		{"punt", func(c govppapi.InterfaceCounters) uint64 { return c.Punts }},
+		{"ip4", func(c govppapi.InterfaceCounters) uint64 { return c.IP4 }},
+		{"ip6", func(c govppapi.InterfaceCounters) uint64 { return c.IP6 }},
+		{"rx-no-buf", func(c govppapi.InterfaceCounters) uint64 { return c.RxNoBuf }},
+		{"rx-miss", func(c govppapi.InterfaceCounters) uint64 { return c.RxMiss }},
+		{"rx-error", func(c govppapi.InterfaceCounters) uint64 { return c.RxErrors }},
+		{"tx-error", func(c govppapi.InterfaceCounters) uint64 { return c.TxErrors }},
+		{"mpls", func(c govppapi.InterfaceCounters) uint64 { return c.Mpls }},
+	}
+	for _, c := range ifaceSimple {
+		values := make([]uint64, len(ifaces.Interfaces))
+		for i, iface := range ifaces.Interfaces {
+			values[i] = c.value(iface)
+			entries = append(entries, api.StatEntry{
+				Name:       "/interfaces/" + iface.InterfaceName + "/" + c.name,
+				Type:       api.StatCounter,
+				Symlink:    true,
+				Counters:   [][]uint64{{values[i]}},
+				Aggregated: true,
+			})
+		}
+		entries = append(entries, api.StatEntry{Name: "/if/" + c.name, Type: api.StatCounter, Counters: [][]uint64{values}, Aggregated: true})
+	}
+	ifaceCombined := []struct {
+		name  string
+		value func(govppapi.InterfaceCounters) govppapi.InterfaceCounterCombined
+	}{
+		{"rx", func(c govppapi.InterfaceCounters) govppapi.InterfaceCounterCombined { return c.Rx }},
+		{"rx-unicast", func(c govppapi.InterfaceCounters) govppapi.InterfaceCounterCombined { return c.RxUnicast }},
+		{"rx-multicast", func(c govppapi.InterfaceCounters) govppapi.InterfaceCounterCombined { return c.RxMulticast }},
+		{"rx-broadcast", func(c govppapi.InterfaceCounters) govppapi.InterfaceCounterCombined { return c.RxBroadcast }},
+		{"tx", func(c govppapi.InterfaceCounters) govppapi.InterfaceCounterCombined { return c.Tx }},
+		{"tx-unicast", func(c govppapi.InterfaceCounters) govppapi.InterfaceCounterCombined { return c.TxUnicast }},
+		{"tx-multicast", func(c govppapi.InterfaceCounters) govppapi.InterfaceCounterCombined { return c.TxMulticast }},
+		{"tx-broadcast", func(c govppapi.InterfaceCounters) govppapi.InterfaceCounterCombined { return c.TxBroadcast }},
+	}
+	for _, c := range ifaceCombined {
+		values := make([]api.CombinedCounter, len(ifaces.Interfaces))
+		for i, iface := range ifaces.Interfaces {
+			v := c.value(iface)
+			values[i] = api.CombinedCounter{Packets: v.Packets, Bytes: v.Bytes}
+			entries = append(entries, api.StatEntry{
+				Name:       "/interfaces/" + iface.InterfaceName + "/" + c.name,
+				Type:       api.StatCombined,
+				Symlink:    true,
+				Combined:   [][]api.CombinedCounter{{values[i]}},
+				Aggregated: true,
+			})
+		}
+		entries = append(entries, api.StatEntry{Name: "/if/" + c.name, Type: api.StatCombined, Combined: [][]api.CombinedCounter{values}, Aggregated: true})
+	}
+
+	for _, e := range errs.Errors {
+		entries = append(entries, api.StatEntry{Name: e.CounterName, Type: api.StatError, Errors: e.Values})
+	}
+
+	for name, pool := range bufs.Buffer {
+		scalar("/buffer-pools/"+name+"/cached", pool.Cached)
+		scalar("/buffer-pools/"+name+"/used", pool.Used)
+		scalar("/buffer-pools/"+name+"/available", pool.Available)
+	}
+
+	return entries, nil
+}
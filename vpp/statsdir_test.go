@@ -0,0 +1,91 @@
+package vpp
+
+import (
+	"reflect"
+	"testing"
+
+	"go.fd.io/govpp/adapter"
+	govppapi "go.fd.io/govpp/api"
+
+	"go.ligato.io/vpp-probe/vpp/api"
+)
+
+type fakeStats struct {
+	govppapi.StatsProvider
+	nodes  govppapi.NodeStats
+	errors govppapi.ErrorStats
+}
+
+func (s *fakeStats) GetSystemStats(*govppapi.SystemStats) error       { return nil }
+func (s *fakeStats) GetInterfaceStats(*govppapi.InterfaceStats) error { return nil }
+func (s *fakeStats) GetBufferStats(*govppapi.BufferStats) error       { return nil }
+func (s *fakeStats) GetNodeStats(n *govppapi.NodeStats) error {
+	*n = s.nodes
+	return nil
+}
+func (s *fakeStats) GetErrorStats(e *govppapi.ErrorStats) error {
+	*e = s.errors
+	return nil
+}
+
+type fakeStatsDir struct {
+	fakeStats
+	patterns []string
+	entries  []adapter.StatEntry
+}
+
+func (s *fakeStatsDir) DumpStats(patterns ...string) ([]adapter.StatEntry, error) {
+	s.patterns = patterns
+	return s.entries, nil
+}
+
+func TestGetStats(t *testing.T) {
+	typed := &fakeStats{
+		errors: govppapi.ErrorStats{Errors: []govppapi.ErrorCounter{
+			{CounterName: "/err/ip4-input/drop", Values: []uint64{1, 2}},
+			{CounterName: "/err/ip4-input/ok", Values: []uint64{3, 4}},
+		}},
+		nodes: govppapi.NodeStats{Nodes: []govppapi.NodeCounters{
+			{NodeName: "ip4-input", Clocks: 30},
+		}},
+	}
+	got, err := GetStats(typed, "/err/*drop*", "/nodes/*/clocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []api.StatEntry{
+		{Name: "/err/ip4-input/drop", Type: api.StatError, Errors: []uint64{1, 2}},
+		{Name: "/nodes/ip4-input/clocks", Type: api.StatCounter, Symlink: true, Counters: [][]uint64{{30}}, Aggregated: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetStats() typed = %+v, want %+v", got, want)
+	}
+
+	dir := &fakeStatsDir{
+		entries: []adapter.StatEntry{
+			{
+				StatIdentifier: adapter.StatIdentifier{Name: []byte("/nodes/ip4-input/clocks")},
+				Data:           adapter.SimpleCounterStat{{10}, {20}},
+				Symlink:        true,
+			},
+			{
+				StatIdentifier: adapter.StatIdentifier{Name: []byte("/if/rx")},
+				Data:           adapter.CombinedCounterStat{{{1, 100}}},
+			},
+		},
+	}
+	got, err = GetStats(dir, "/nodes/*/clocks", "/if/rx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []api.StatEntry{
+		{Name: "/if/rx", Type: api.StatCombined, Combined: [][]api.CombinedCounter{{{Packets: 1, Bytes: 100}}}},
+		{Name: "/nodes/ip4-input/clocks", Type: api.StatCounter, Symlink: true, Counters: [][]uint64{{10}, {20}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetStats() dir = %+v, want %+v", got, want)
+	}
+	if wantPatterns := []string{`^/nodes/.*/clocks$`, `^/if/rx$`}; !reflect.DeepEqual(dir.patterns, wantPatterns) {
+		t.Errorf("patterns = %q, want %q", dir.patterns, wantPatterns)
+	}
+}
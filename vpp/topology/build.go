@@ -1,11 +1,16 @@
 package topology
 
 import (
+	"encoding/base64"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	linux_interfaces "go.ligato.io/vpp-agent/v3/proto/ligato/linux/interfaces"
 	vpp_interfaces "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/interfaces"
+	vpp_wg "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/wireguard"
+	"golang.org/x/crypto/curve25519"
 
 	"go.ligato.io/vpp-probe/vpp"
 	"go.ligato.io/vpp-probe/vpp/agent"
@@ -17,10 +22,15 @@ func Build(instances []*vpp.Instance) (*Info, error) {
 	logrus.Debugf("building topology info for %v instances", len(instances))
 
 	for _, instance := range instances {
+		config := instance.Config()
+		if config == nil {
+			logrus.Debugf("skipping instance %v without config", instance.ID())
+			continue
+		}
 		logrus.Debugf("correlating instance: %+v", instance)
 
 		// correlate VPP interfaces
-		for i, iface := range instance.Agent().Config.VPP.Interfaces {
+		for i, iface := range config.VPP.Interfaces {
 			switch iface.Value.GetType() {
 			case vpp_interfaces.Interface_MEMIF:
 				s.correlateMemif(instance, i)
@@ -28,15 +38,30 @@ func Build(instances []*vpp.Instance) (*Info, error) {
 				s.correlateAfPacket(instance, i)
 			case vpp_interfaces.Interface_TAP:
 				s.correlateTapToHost(instance, i)
-			case vpp_interfaces.Interface_VXLAN_TUNNEL:
-				s.correlateVxlanTunnel(instance, i)
+			case vpp_interfaces.Interface_VXLAN_TUNNEL,
+				vpp_interfaces.Interface_GRE_TUNNEL,
+				vpp_interfaces.Interface_IPIP_TUNNEL,
+				vpp_interfaces.Interface_GTPU_TUNNEL:
+				s.correlateTunnel(instance, i)
+			case vpp_interfaces.Interface_WIREGUARD_TUNNEL:
+				s.correlateWireguard(instance, i)
+			case vpp_interfaces.Interface_BOND_INTERFACE:
+				s.correlateBond(instance, i)
+			case vpp_interfaces.Interface_SUB_INTERFACE:
+				s.correlateSubInterface(instance, i)
 			default:
-				logrus.Debugf("correlation for vpp interface type %v not implemented", iface.Value.GetType())
+				if iface.Metadata["VhostSocket"] != nil {
+					s.correlateVhostUser(instance, i)
+				} else if iface.Metadata["TunnelType"] != nil {
+					s.correlateTunnel(instance, i)
+				} else {
+					logrus.Debugf("correlation for vpp interface type %v not implemented", iface.Value.GetType())
+				}
 			}
 		}
 
 		// correlate Linux interfaces
-		for i, iface := range instance.Agent().Config.Linux.Interfaces {
+		for i, iface := range config.Linux.Interfaces {
 			switch iface.Value.GetType() {
 			case linux_interfaces.Interface_VETH:
 				s.correlateVeth(instance, i)
@@ -48,7 +73,7 @@ func Build(instances []*vpp.Instance) (*Info, error) {
 		}
 
 		// correlate other relations
-		if l2xconnects := instance.Agent().Config.VPP.L2XConnects; len(l2xconnects) > 0 {
+		if l2xconnects := config.VPP.L2XConnects; len(l2xconnects) > 0 {
 			s.correlateL2xconnects(instance, l2xconnects)
 		}
 	}
@@ -59,19 +84,104 @@ func Build(instances []*vpp.Instance) (*Info, error) {
 	}
 
 	info := &Info{
+		Networks:    collectNetworks(instances, connections),
 		Connections: connections,
+		Unpaired:    s.unpaired,
 	}
 
 	return info, nil
 }
 
+// collectNetworks returns VPP and Linux networks of instances with their
+// interfaces attached as endpoints. Endpoints of connections that are not
+// found in instance config are attached too.
+func collectNetworks(instances []*vpp.Instance, connections []Connection) []NetworkInfo {
+	var networks []NetworkInfo
+	networkIdx := map[Network]int{}
+	instanceMeta := map[string]map[string]string{}
+	seen := map[string]bool{}
+
+	attach := func(e Endpoint) {
+		id := endpointID(e)
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+		idx, ok := networkIdx[e.Network]
+		if !ok {
+			meta := instanceMeta[e.Instance]
+			networks = append(networks, NetworkInfo{
+				Network: e.Network,
+				Cluster: meta["cluster"],
+				Node:    meta["node"],
+			})
+			idx = len(networks) - 1
+			networkIdx[e.Network] = idx
+		}
+		networks[idx].Endpoints = append(networks[idx].Endpoints, e)
+	}
+
+	for _, instance := range instances {
+		instanceMeta[instance.ID()] = instance.Handler().Metadata()
+
+		config := instance.Config()
+		if config == nil {
+			continue
+		}
+		for _, iface := range config.VPP.Interfaces {
+			if iface.Index() == 0 {
+				continue
+			}
+			iface := iface
+			e := newVppEndpoint(instance, &iface)
+			e.Kind = InterfaceEndpoint
+			if name, ok := iface.Metadata["InternalName"].(string); ok {
+				e.addMetadata("internal", name)
+			}
+			if ips := iface.Value.GetIpAddresses(); len(ips) > 0 {
+				e.addMetadata("ips", strings.Join(ips, ","))
+			}
+			attach(e)
+		}
+		for _, iface := range config.Linux.Interfaces {
+			iface := iface
+			e := newLinuxEndpoint(instance, &iface)
+			e.Kind = InterfaceEndpoint
+			e.addMetadata("internal", iface.Value.GetHostIfName())
+			if ips := iface.Value.GetIpAddresses(); len(ips) > 0 {
+				e.addMetadata("ips", strings.Join(ips, ","))
+			}
+			attach(e)
+		}
+	}
+
+	for _, conn := range connections {
+		for _, e := range []Endpoint{conn.Source, conn.Destination} {
+			if e.Kind == FileEndpoint || e.Instance == "" {
+				continue
+			}
+			attach(e)
+		}
+	}
+
+	return networks
+}
+
 type buildCtx struct {
 	instances   []*vpp.Instance
 	connections []*Connection
+	unpaired    []Endpoint
 }
 
 func newBuildCtx(instances []*vpp.Instance) *buildCtx {
-	return &buildCtx{instances: instances}
+	s := &buildCtx{}
+	// only instances with config can be correlated as peers
+	for _, instance := range instances {
+		if instance.Config() != nil {
+			s.instances = append(s.instances, instance)
+		}
+	}
+	return s
 }
 
 func (s *buildCtx) addConn(typ string, src, dst Endpoint) *Connection {
@@ -92,8 +202,25 @@ func (s *buildCtx) addConn(typ string, src, dst Endpoint) *Connection {
 	return conn
 }
 
+// addUnpaired records endpoint of type typ whose expected peer was not found.
+func (s *buildCtx) addUnpaired(typ string, e Endpoint, peer string) *Endpoint {
+	if e.Kind == UnknownEndpointType {
+		e.Kind = InterfaceEndpoint
+	}
+	// copy metadata shared with endpoints of connections
+	metadata := map[string]string{}
+	for k, v := range e.Metadata {
+		metadata[k] = v
+	}
+	e.Metadata = metadata
+	e.addMetadata("type", typ)
+	e.addMetadata("peer", peer)
+	s.unpaired = append(s.unpaired, e)
+	return &s.unpaired[len(s.unpaired)-1]
+}
+
 func (s *buildCtx) correlateMemif(instance *vpp.Instance, ifaceIdx int) {
-	iface := instance.Agent().Config.VPP.Interfaces[ifaceIdx]
+	iface := instance.Config().VPP.Interfaces[ifaceIdx]
 	memif1 := iface.Value.GetMemif()
 
 	log := logrus.WithFields(map[string]interface{}{
@@ -103,17 +230,13 @@ func (s *buildCtx) correlateMemif(instance *vpp.Instance, ifaceIdx int) {
 	})
 	log.Debugf("correlating memif interface: %v", memif1)
 
-	memifEndpoint := Endpoint{
-		Network:   newVppNetwork(instance),
-		Interface: iface.Value.GetName(),
-	}
-	memifEndpoint.addMetadata("state", getVppIfaceState(&iface))
+	memifEndpoint := newVppEndpoint(instance, &iface)
+	inode, hasInode := socketInode(iface)
 
 	var conns []*Connection
 
 	for _, instance2 := range s.instances {
-		vppNetwork2 := newVppNetwork(instance2)
-		for i, iface2 := range instance2.Agent().Config.VPP.Interfaces {
+		for i, iface2 := range instance2.Config().VPP.Interfaces {
 			if instance.ID() == instance2.ID() && (i == ifaceIdx || iface.Key == iface2.Key) {
 				continue
 			}
@@ -126,33 +249,97 @@ func (s *buildCtx) correlateMemif(instance *vpp.Instance, ifaceIdx int) {
 			if memif1.GetId() != memif2.GetId() {
 				continue
 			}
-			if iface.Metadata["inode"] != iface2.Metadata["inode"] {
+			if inode2, ok := socketInode(iface2); !hasInode || !ok || inode != inode2 {
 				continue
 			}
 
 			log.Debugf("found matching memif interface on instance %v: %+v", instance2, memif2)
 
-			conn := s.addConn("memif-sock", memifEndpoint, Endpoint{
-				Network:   vppNetwork2,
-				Interface: iface2.Value.GetName(),
-			})
+			iface2 := iface2
+			conn := s.addConn("memif-sock", memifEndpoint, newVppEndpoint(instance2, &iface2))
 
 			conns = append(conns, conn)
 		}
 	}
 
 	if len(conns) == 0 {
-		inode := fmt.Sprint(iface.Metadata["inode"])
 		s.addConn("memif-sock", memifEndpoint, Endpoint{
-			Interface: fmt.Sprintf("inode %v", inode),
+			Interface: socketFileName(memifEndpoint, inode, hasInode),
 			Kind:      FileEndpoint,
 		}).addMetadata("state", "down").
 			addMetadata("label", memif1.GetSocketFilename())
+		s.addUnpaired("memif-sock", memifEndpoint, memif1.GetSocketFilename())
 	}
 }
 
+func (s *buildCtx) correlateVhostUser(instance *vpp.Instance, ifaceIdx int) {
+	iface := instance.Config().VPP.Interfaces[ifaceIdx]
+	socket := fmt.Sprint(iface.Metadata["VhostSocket"])
+
+	log := logrus.WithFields(map[string]interface{}{
+		"instance": instance.ID(),
+		"ifaceIdx": ifaceIdx,
+		"inode":    iface.Metadata["inode"],
+	})
+	log.Debugf("correlating vhost-user interface: %v", socket)
+
+	vhostEndpoint := newVppEndpoint(instance, &iface)
+	inode, hasInode := socketInode(iface)
+
+	var conns []*Connection
+
+	for _, instance2 := range s.instances {
+		for i, iface2 := range instance2.Config().VPP.Interfaces {
+			if instance.ID() == instance2.ID() && (i == ifaceIdx || iface.Key == iface2.Key) {
+				continue
+			}
+			if iface2.Metadata["VhostSocket"] == nil {
+				continue
+			}
+			if inode2, ok := socketInode(iface2); !hasInode || !ok || inode != inode2 {
+				continue
+			}
+
+			log.Debugf("found matching vhost-user interface on instance %v: %v", instance2, iface2.Value.GetName())
+
+			iface2 := iface2
+			conn := s.addConn("vhost-sock", vhostEndpoint, newVppEndpoint(instance2, &iface2))
+			conns = append(conns, conn)
+		}
+	}
+
+	if len(conns) == 0 {
+		s.addConn("vhost-sock", vhostEndpoint, Endpoint{
+			Interface: socketFileName(vhostEndpoint, inode, hasInode),
+			Kind:      FileEndpoint,
+		}).addMetadata("state", "down").
+			addMetadata("label", socket)
+		s.addUnpaired("vhost-sock", vhostEndpoint, socket)
+	}
+}
+
+// socketInode returns inode of socket file used by interface. Inode that is
+// zero or missing means socket file was not found and cannot be matched.
+func socketInode(iface agent.VppInterface) (string, bool) {
+	v, ok := iface.Metadata["inode"]
+	if !ok || v == nil {
+		return "", false
+	}
+	inode := fmt.Sprint(v)
+	return inode, inode != "0" && inode != ""
+}
+
+// socketFileName returns name of file endpoint for socket of interface
+// endpoint e. Socket with unknown inode is not shared with other interfaces.
+func socketFileName(e Endpoint, inode string, hasInode bool) string {
+	if !hasInode {
+		return fmt.Sprintf("inode ? (%s)", endpointName(e))
+	}
+	return fmt.Sprintf("inode %v", inode)
+}
+
 func (s *buildCtx) correlateAfPacket(instance *vpp.Instance, ifaceIdx int) {
-	iface := instance.Agent().Config.VPP.Interfaces[ifaceIdx]
+	iface := instance.Config().VPP.Interfaces[ifaceIdx]
 	afPacket := iface.Value.GetAfpacket()
 
 	hostIfName := afPacket.GetHostIfName()
@@ -166,7 +353,7 @@ func (s *buildCtx) correlateAfPacket(instance *vpp.Instance, ifaceIdx int) {
 
 	var hostIface *agent.LinuxInterface
 
-	for _, linuxIface := range instance.Agent().Config.Linux.Interfaces {
+	for _, linuxIface := range instance.Config().Linux.Interfaces {
 		if linuxIface.Value.GetHostIfName() != hostIfName {
 			continue
 		}
@@ -182,21 +369,15 @@ func (s *buildCtx) correlateAfPacket(instance *vpp.Instance, ifaceIdx int) {
 		return
 	}
 
-	afPacketEndpoint := Endpoint{
-		Network:   newVppNetwork(instance),
-		Interface: iface.Value.GetName(),
-	}
-	vethEndpoint := Endpoint{
-		Network:   newLinuxNetwork(instance, hostIface.Value.GetNamespace().GetReference()),
-		Interface: hostIface.Value.GetName(),
-	}
+	afPacketEndpoint := newVppEndpoint(instance, &iface)
+	vethEndpoint := newLinuxEndpoint(instance, hostIface)
 
 	s.addConn("afpacket-to-host", afPacketEndpoint, vethEndpoint)
 	s.addConn("host-to-afpacket", vethEndpoint, afPacketEndpoint)
 }
 
 func (s *buildCtx) correlateVeth(instance *vpp.Instance, ifaceIdx int) {
-	iface := instance.Agent().Config.Linux.Interfaces[ifaceIdx]
+	iface := instance.Config().Linux.Interfaces[ifaceIdx]
 	veth := iface.Value.GetVeth()
 
 	log := logrus.WithFields(map[string]interface{}{
@@ -206,64 +387,233 @@ func (s *buildCtx) correlateVeth(instance *vpp.Instance, ifaceIdx int) {
 	})
 	log.Debugf("correlating veth interface: %v", veth)
 
-	iface2 := instance.Agent().Config.GetLinuxInterface(iface.Value.GetVeth().PeerIfName)
+	iface2 := instance.Config().GetLinuxInterface(iface.Value.GetVeth().PeerIfName)
 	if iface2 == nil {
 		log.Warnf("could not find veth peer for interface: %v", iface)
+		s.addUnpaired("veth-pair", newLinuxEndpoint(instance, &iface), veth.GetPeerIfName())
 		return
 	}
 
-	s.addConn("veth-pair", Endpoint{
-		Network:   newLinuxNetwork(instance, iface.Value.GetNamespace().GetReference()),
-		Interface: iface.Value.GetName(),
-	}, Endpoint{
-		Network:   newLinuxNetwork(instance, iface2.Value.GetNamespace().GetReference()),
-		Interface: iface2.Value.GetName(),
-	})
+	s.addConn("veth-pair", newLinuxEndpoint(instance, &iface), newLinuxEndpoint(instance, iface2))
 }
 
-func (s *buildCtx) correlateVxlanTunnel(instance *vpp.Instance, ifaceIdx int) {
-	iface := instance.Agent().Config.VPP.Interfaces[ifaceIdx]
-	vxlan := iface.Value.GetVxlan()
+func (s *buildCtx) correlateTunnel(instance *vpp.Instance, ifaceIdx int) {
+	iface := instance.Config().VPP.Interfaces[ifaceIdx]
+	tun := getTunnelInfo(&iface)
 
 	log := logrus.WithFields(map[string]interface{}{
 		"instance": instance.ID(),
 		"ifaceIdx": ifaceIdx,
 	})
-	log.Debugf("correlating vxlan tunnel interface: %v", vxlan)
-
-	srcAddr := vxlan.GetSrcAddress()
-	dstAddr := vxlan.GetDstAddress()
+	log.Debugf("correlating %v tunnel interface: %+v", tun.Type, tun)
 
+	var found bool
 	for _, instance2 := range s.instances {
-		for _, iface2 := range instance2.Agent().Config.VPP.Interfaces {
+		for _, iface2 := range instance2.Config().VPP.Interfaces {
 			if instance.ID() == instance2.ID() && iface.Key == iface2.Key {
 				continue // skip this interface
 			}
-			if iface2.Value.GetType() != vpp_interfaces.Interface_VXLAN_TUNNEL {
-				continue // skip non-vxlan tunnel interfaces
+			tun2 := getTunnelInfo(&iface2)
+			if tun2 == nil || !tun.pairsWith(tun2) {
+				continue
 			}
 
-			vxlan2 := iface2.Value.GetVxlan()
-			if vxlan.GetVni() != vxlan2.GetVni() {
-				continue // skip different vni
-			}
-			if srcAddr != vxlan2.GetDstAddress() || dstAddr != vxlan2.GetSrcAddress() {
-				continue // skip different src/dst addresses
+			iface2 := iface2
+			s.addConn(tun.Type, newVppEndpoint(instance, &iface), newVppEndpoint(instance2, &iface2))
+			found = true
+		}
+	}
+
+	if !found {
+		log.Debugf("could not find other end of %v tunnel %v", tun.Type, iface.Value.GetName())
+		s.addUnpaired(tun.Type, newVppEndpoint(instance, &iface), tun.Dst).
+			addMetadata("tunnel-src", tun.Src).
+			addMetadata("tunnel-dst", tun.Dst).
+			addMetadata("vni", fmt.Sprint(tun.VNI))
+	}
+}
+
+// tunnelInfo contains endpoints of tunnel interface.
+type tunnelInfo struct {
+	Type       string
+	Src        string
+	Dst        string
+	VNI        uint32
+	Teid       uint32
+	RemoteTeid uint32
+}
+
+func getTunnelInfo(vppIface *agent.VppInterface) *tunnelInfo {
+	iface := vppIface.Value
+	switch iface.GetType() {
+	case vpp_interfaces.Interface_VXLAN_TUNNEL:
+		vxlan := iface.GetVxlan()
+		typ := "vxlan-tun"
+		if vxlan.GetGpe() != nil {
+			typ = "vxlan-gpe-tun"
+		}
+		return &tunnelInfo{
+			Type: typ,
+			Src:  vxlan.GetSrcAddress(),
+			Dst:  vxlan.GetDstAddress(),
+			VNI:  vxlan.GetVni(),
+		}
+	case vpp_interfaces.Interface_GRE_TUNNEL:
+		return &tunnelInfo{
+			Type: "gre-tun",
+			Src:  iface.GetGre().GetSrcAddr(),
+			Dst:  iface.GetGre().GetDstAddr(),
+		}
+	case vpp_interfaces.Interface_IPIP_TUNNEL:
+		return &tunnelInfo{
+			Type: "ipip-tun",
+			Src:  iface.GetIpip().GetSrcAddr(),
+			Dst:  iface.GetIpip().GetDstAddr(),
+		}
+	case vpp_interfaces.Interface_GTPU_TUNNEL:
+		return &tunnelInfo{
+			Type:       "gtpu-tun",
+			Src:        iface.GetGtpu().GetSrcAddr(),
+			Dst:        iface.GetGtpu().GetDstAddr(),
+			Teid:       iface.GetGtpu().GetTeid(),
+			RemoteTeid: iface.GetGtpu().GetRemoteTeid(),
+		}
+	}
+	// tunnels without model in agent have endpoints in metadata
+	if typ, ok := vppIface.Metadata["TunnelType"].(string); ok && typ != "" {
+		return &tunnelInfo{
+			Type: typ + "-tun",
+			Src:  fmt.Sprint(vppIface.Metadata["TunnelSrc"]),
+			Dst:  fmt.Sprint(vppIface.Metadata["TunnelDst"]),
+			VNI:  metadataUint(vppIface.Metadata, "TunnelVNI"),
+		}
+	}
+	return nil
+}
+
+// pairsWith returns true if tun2 is the other end of the tunnel.
+func (tun *tunnelInfo) pairsWith(tun2 *tunnelInfo) bool {
+	if tun.Type != tun2.Type || tun.VNI != tun2.VNI {
+		return false
+	}
+	if tun.Src != tun2.Dst || tun.Dst != tun2.Src {
+		return false
+	}
+	if tun.RemoteTeid != 0 || tun2.RemoteTeid != 0 {
+		return tun.Teid == tun2.RemoteTeid && tun.RemoteTeid == tun2.Teid
+	}
+	return tun.Teid == tun2.Teid
+}
+
+func (s *buildCtx) correlateWireguard(instance *vpp.Instance, ifaceIdx int) {
+	iface := instance.Config().VPP.Interfaces[ifaceIdx]
+
+	log := logrus.WithFields(map[string]interface{}{
+		"instance": instance.ID(),
+		"ifaceIdx": ifaceIdx,
+	})
+	log.Debugf("correlating wireguard interface: %v", iface.Value.GetWireguard())
+
+	wgEndpoint := newVppEndpoint(instance, &iface)
+
+	for _, peer := range instance.Config().VPP.WgPeers {
+		if peer.Value.GetWgIfName() != iface.Value.GetName() {
+			continue
+		}
+		var found bool
+		for _, instance2 := range s.instances {
+			for _, iface2 := range instance2.Config().VPP.Interfaces {
+				if instance.ID() == instance2.ID() && iface.Key == iface2.Key {
+					continue
+				}
+				if iface2.Value.GetType() != vpp_interfaces.Interface_WIREGUARD_TUNNEL {
+					continue
+				}
+				if !isWireguardPeerOf(peer.Value, &iface2) {
+					continue
+				}
+
+				iface2 := iface2
+				s.addConn("wireguard-peer", wgEndpoint, newVppEndpoint(instance2, &iface2))
+				found = true
 			}
+		}
+		if !found {
+			log.Debugf("could not find wireguard interface for peer %v", peer.Value.GetEndpoint())
+		}
+	}
+}
 
-			s.addConn("vxlan-tun", Endpoint{
-				Network:   newVppNetwork(instance),
-				Interface: iface.Value.Name,
-			}, Endpoint{
-				Network:   newVppNetwork(instance2),
-				Interface: iface2.Value.Name,
-			})
+// isWireguardPeerOf returns true if peer refers to wireguard interface iface.
+// The public key of interface is used if known or derived from its private
+// key, otherwise peer endpoint is compared with interface address and port.
+func isWireguardPeerOf(peer *vpp_wg.Peer, iface *agent.VppInterface) bool {
+	wg := iface.Value.GetWireguard()
+	key, _ := iface.Metadata["PublicKey"].(string)
+	if key == "" {
+		key = wireguardPublicKey(wg.GetPrivateKey())
+	}
+	if key != "" {
+		return peer.GetPublicKey() == key
+	}
+	return peer.GetEndpoint() == wg.GetSrcAddr() && peer.GetPort() == wg.GetPort()
+}
+
+// wireguardPublicKey returns base64 encoded public key for base64 encoded
+// private key or empty string if the private key is not valid.
+func wireguardPublicKey(privateKey string) string {
+	priv, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil || len(priv) != curve25519.ScalarSize {
+		return ""
+	}
+	pub, err := curve25519.X25519(priv, curve25519.Basepoint)
+	if err != nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(pub)
+}
+
+func (s *buildCtx) correlateBond(instance *vpp.Instance, ifaceIdx int) {
+	iface := instance.Config().VPP.Interfaces[ifaceIdx]
+	bond := iface.Value.GetBond()
+
+	log := logrus.WithFields(map[string]interface{}{
+		"instance": instance.ID(),
+		"ifaceIdx": ifaceIdx,
+	})
+	log.Debugf("correlating bond interface: %v", bond)
+
+	for _, member := range bond.GetBondedInterfaces() {
+		iface2 := instance.Config().GetVppInterface(member.GetName())
+		if iface2 == nil {
+			log.Warnf("could not find member %v of bond %v", member.GetName(), iface.Value.GetName())
+			continue
 		}
+		s.addConn("bond-member", newVppEndpoint(instance, &iface), newVppEndpoint(instance, iface2))
 	}
 }
 
+func (s *buildCtx) correlateSubInterface(instance *vpp.Instance, ifaceIdx int) {
+	iface := instance.Config().VPP.Interfaces[ifaceIdx]
+	sub := iface.Value.GetSub()
+
+	log := logrus.WithFields(map[string]interface{}{
+		"instance": instance.ID(),
+		"ifaceIdx": ifaceIdx,
+	})
+	log.Debugf("correlating sub-interface: %v", sub)
+
+	parent := instance.Config().GetVppInterface(sub.GetParentName())
+	if parent == nil {
+		log.Warnf("could not find parent %v of sub-interface %v", sub.GetParentName(), iface.Value.GetName())
+		return
+	}
+
+	s.addConn("sub-parent", newVppEndpoint(instance, &iface), newVppEndpoint(instance, parent)).addMetadata("sub-id", fmt.Sprint(sub.GetSubId()))
+}
+
 func (s *buildCtx) correlateTapToVPP(instance *vpp.Instance, ifaceIdx int) {
-	iface := instance.Agent().Config.Linux.Interfaces[ifaceIdx]
+	iface := instance.Config().Linux.Interfaces[ifaceIdx]
 	tapToVPP := iface.Value.GetTap()
 
 	log := logrus.WithFields(map[string]interface{}{
@@ -272,23 +622,17 @@ func (s *buildCtx) correlateTapToVPP(instance *vpp.Instance, ifaceIdx int) {
 	})
 	log.Debugf("correlating tap to vpp interface: %v", tapToVPP)
 
-	iface2 := instance.Agent().Config.GetVppInterface(tapToVPP.GetVppTapIfName())
+	iface2 := instance.Config().GetVppInterface(tapToVPP.GetVppTapIfName())
 	if iface2 == nil {
 		log.Warnf("could not find vpp tap for: %v", iface)
 		return
 	}
 
-	s.addConn("host-to-tap", Endpoint{
-		Network:   newLinuxNetwork(instance, iface.Value.GetNamespace().GetReference()),
-		Interface: iface.Value.GetName(),
-	}, Endpoint{
-		Network:   newVppNetwork(instance),
-		Interface: iface2.Value.GetName(),
-	})
+	s.addConn("host-to-tap", newLinuxEndpoint(instance, &iface), newVppEndpoint(instance, iface2))
 }
 
 func (s *buildCtx) correlateTapToHost(instance *vpp.Instance, ifaceIdx int) {
-	iface := instance.Agent().Config.VPP.Interfaces[ifaceIdx]
+	iface := instance.Config().VPP.Interfaces[ifaceIdx]
 	tap := iface.Value.GetTap()
 
 	log := logrus.WithFields(map[string]interface{}{
@@ -299,7 +643,7 @@ func (s *buildCtx) correlateTapToHost(instance *vpp.Instance, ifaceIdx int) {
 
 	var hostIface *agent.LinuxInterface
 
-	for _, linuxIface := range instance.Agent().Config.Linux.Interfaces {
+	for _, linuxIface := range instance.Config().Linux.Interfaces {
 		if linuxIface.Value.GetTap().GetVppTapIfName() != iface.Value.GetName() {
 			continue
 		}
@@ -315,13 +659,7 @@ func (s *buildCtx) correlateTapToHost(instance *vpp.Instance, ifaceIdx int) {
 		return
 	}
 
-	s.addConn("tap-to-host", Endpoint{
-		Network:   newVppNetwork(instance),
-		Interface: iface.Value.GetName(),
-	}, Endpoint{
-		Network:   newLinuxNetwork(instance, hostIface.Value.GetNamespace().GetReference()),
-		Interface: hostIface.Value.GetName(),
-	})
+	s.addConn("tap-to-host", newVppEndpoint(instance, &iface), newLinuxEndpoint(instance, hostIface))
 }
 
 func (s *buildCtx) correlateL2xconnects(instance *vpp.Instance, l2xconnects []agent.VppL2XConnect) {
@@ -332,7 +670,7 @@ func (s *buildCtx) correlateL2xconnects(instance *vpp.Instance, l2xconnects []ag
 
 	vppNetwork := newVppNetwork(instance)
 
-	for _, l2xc := range instance.Agent().Config.VPP.L2XConnects {
+	for _, l2xc := range instance.Config().VPP.L2XConnects {
 		s.addConn("l2xconn", Endpoint{
 			Network:   vppNetwork,
 			Interface: l2xc.Value.GetTransmitInterface(),
@@ -343,6 +681,36 @@ func (s *buildCtx) correlateL2xconnects(instance *vpp.Instance, l2xconnects []ag
 	}
 }
 
+// newVppEndpoint returns endpoint of VPP interface with its state and MTU.
+func newVppEndpoint(instance *vpp.Instance, iface *agent.VppInterface) Endpoint {
+	e := Endpoint{
+		Network:   newVppNetwork(instance),
+		Interface: iface.Value.GetName(),
+	}
+	e.addMetadata("state", getVppIfaceState(iface))
+	if mtu := iface.Value.GetMtu(); mtu > 0 {
+		e.addMetadata("mtu", fmt.Sprint(mtu))
+	}
+	return e
+}
+
+// newLinuxEndpoint returns endpoint of Linux interface with its state and MTU.
+func newLinuxEndpoint(instance *vpp.Instance, iface *agent.LinuxInterface) Endpoint {
+	e := Endpoint{
+		Network:   newLinuxNetwork(instance, iface.Value.GetNamespace().GetReference()),
+		Interface: iface.Value.GetName(),
+	}
+	state := "up"
+	if !iface.Value.GetEnabled() {
+		state = "down"
+	}
+	e.addMetadata("state", state)
+	if mtu := iface.Value.GetMtu(); mtu > 0 {
+		e.addMetadata("mtu", fmt.Sprint(mtu))
+	}
+	return e
+}
+
 func getVppIfaceState(iface *agent.VppInterface) string {
 	if !iface.Value.GetEnabled() {
 		return "down"
@@ -352,3 +720,10 @@ func getVppIfaceState(iface *agent.VppInterface) string {
 	}
 	return "up"
 }
+
+// metadataUint returns metadata value as uint32, values decoded from
+// JSON are float64.
+func metadataUint(metadata map[string]interface{}, key string) uint32 {
+	v, _ := strconv.ParseFloat(fmt.Sprint(metadata[key]), 64)
+	return uint32(v)
+}
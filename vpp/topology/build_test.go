@@ -0,0 +1,452 @@
+package topology
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	linux_interfaces "go.ligato.io/vpp-agent/v3/proto/ligato/linux/interfaces"
+	linux_namespace "go.ligato.io/vpp-agent/v3/proto/ligato/linux/namespace"
+	vpp_interfaces "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/interfaces"
+	vpp_wg "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/wireguard"
+
+	"go.ligato.io/vpp-probe/vpp"
+	"go.ligato.io/vpp-probe/vpp/agent"
+)
+
+func testInstance(id string, ifaces []agent.VppInterface, peers ...*vpp_wg.Peer) *vpp.Instance {
+	config := &agent.Config{}
+	for _, iface := range ifaces {
+		iface.Key = vpp_interfaces.InterfaceKey(iface.Value.GetName())
+		config.VPP.Interfaces = append(config.VPP.Interfaces, iface)
+	}
+	for _, peer := range peers {
+		config.VPP.WgPeers = append(config.VPP.WgPeers, agent.VppWgPeer{Value: peer})
+	}
+	return vpp.NewOfflineInstance(id, nil, config)
+}
+
+func testIface(value *vpp_interfaces.Interface, metadata map[string]interface{}) agent.VppInterface {
+	value.Enabled = true
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	metadata["linkstate"] = true
+	return agent.VppInterface{
+		KVData: agent.KVData{Metadata: metadata},
+		Value:  value,
+	}
+}
+
+// connStrings returns connections as sorted strings prefixed by type.
+func connStrings(info *Info) []string {
+	var list []string
+	for _, conn := range info.Connections {
+		list = append(list, conn.Metadata["type"]+" "+conn.String())
+	}
+	sort.Strings(list)
+	return list
+}
+
+func TestBuildCorrelators(t *testing.T) {
+	tests := []struct {
+		name      string
+		instances []*vpp.Instance
+		want      []string
+	}{
+		{
+			name: "vhost-user",
+			instances: []*vpp.Instance{
+				testInstance("a", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{Name: "VirtualEthernet0/0/0"},
+						map[string]interface{}{"VhostSocket": "/run/vhost.sock", "inode": 42}),
+				}),
+				testInstance("b", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{Name: "VirtualEthernet0/0/1"},
+						map[string]interface{}{"VhostSocket": "/var/vhost.sock", "inode": 42}),
+				}),
+			},
+			want: []string{
+				`vhost-sock "instance::a/VirtualEthernet0/0/0" -> "instance::b/VirtualEthernet0/0/1"`,
+				`vhost-sock "instance::b/VirtualEthernet0/0/1" -> "instance::a/VirtualEthernet0/0/0"`,
+			},
+		},
+		{
+			name: "ipip tunnel",
+			instances: []*vpp.Instance{
+				testInstance("a", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{
+						Name: "ipip0",
+						Type: vpp_interfaces.Interface_IPIP_TUNNEL,
+						Link: &vpp_interfaces.Interface_Ipip{Ipip: &vpp_interfaces.IPIPLink{SrcAddr: "10.0.0.1", DstAddr: "10.0.0.2"}},
+					}, nil),
+				}),
+				testInstance("b", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{
+						Name: "ipip1",
+						Type: vpp_interfaces.Interface_IPIP_TUNNEL,
+						Link: &vpp_interfaces.Interface_Ipip{Ipip: &vpp_interfaces.IPIPLink{SrcAddr: "10.0.0.2", DstAddr: "10.0.0.1"}},
+					}, nil),
+					testIface(&vpp_interfaces.Interface{
+						Name: "ipip2",
+						Type: vpp_interfaces.Interface_IPIP_TUNNEL,
+						Link: &vpp_interfaces.Interface_Ipip{Ipip: &vpp_interfaces.IPIPLink{SrcAddr: "10.0.0.2", DstAddr: "10.0.0.3"}},
+					}, nil),
+				}),
+			},
+			want: []string{
+				`ipip-tun "instance::a/ipip0" -> "instance::b/ipip1"`,
+				`ipip-tun "instance::b/ipip1" -> "instance::a/ipip0"`,
+			},
+		},
+		{
+			name: "gre tunnel",
+			instances: []*vpp.Instance{
+				testInstance("a", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{
+						Name: "gre0",
+						Type: vpp_interfaces.Interface_GRE_TUNNEL,
+						Link: &vpp_interfaces.Interface_Gre{Gre: &vpp_interfaces.GreLink{SrcAddr: "10.0.0.1", DstAddr: "10.0.0.2"}},
+					}, nil),
+				}),
+				testInstance("b", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{
+						Name: "gre0",
+						Type: vpp_interfaces.Interface_GRE_TUNNEL,
+						Link: &vpp_interfaces.Interface_Gre{Gre: &vpp_interfaces.GreLink{SrcAddr: "10.0.0.2", DstAddr: "10.0.0.1"}},
+					}, nil),
+				}),
+			},
+			want: []string{
+				`gre-tun "instance::a/gre0" -> "instance::b/gre0"`,
+				`gre-tun "instance::b/gre0" -> "instance::a/gre0"`,
+			},
+		},
+		{
+			name: "geneve tunnel",
+			instances: []*vpp.Instance{
+				testInstance("a", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{Name: "geneve_tunnel0"}, map[string]interface{}{
+						"TunnelType": "geneve", "TunnelSrc": "10.0.0.1", "TunnelDst": "10.0.0.2", "TunnelVNI": uint32(5),
+					}),
+				}),
+				testInstance("b", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{Name: "geneve_tunnel0"}, map[string]interface{}{
+						"TunnelType": "geneve", "TunnelSrc": "10.0.0.2", "TunnelDst": "10.0.0.1", "TunnelVNI": float64(5),
+					}),
+					testIface(&vpp_interfaces.Interface{Name: "geneve_tunnel1"}, map[string]interface{}{
+						"TunnelType": "geneve", "TunnelSrc": "10.0.0.2", "TunnelDst": "10.0.0.1", "TunnelVNI": float64(6),
+					}),
+				}),
+			},
+			want: []string{
+				`geneve-tun "instance::a/geneve_tunnel0" -> "instance::b/geneve_tunnel0"`,
+				`geneve-tun "instance::b/geneve_tunnel0" -> "instance::a/geneve_tunnel0"`,
+			},
+		},
+		{
+			name: "vxlan-gpe tunnel",
+			instances: []*vpp.Instance{
+				testInstance("a", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{
+						Name: "vxlan0",
+						Type: vpp_interfaces.Interface_VXLAN_TUNNEL,
+						Link: &vpp_interfaces.Interface_Vxlan{Vxlan: &vpp_interfaces.VxlanLink{
+							SrcAddress: "10.0.0.1", DstAddress: "10.0.0.2", Vni: 10,
+							Gpe: &vpp_interfaces.VxlanLink_Gpe{},
+						}},
+					}, nil),
+				}),
+				testInstance("b", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{
+						Name: "vxlan0",
+						Type: vpp_interfaces.Interface_VXLAN_TUNNEL,
+						Link: &vpp_interfaces.Interface_Vxlan{Vxlan: &vpp_interfaces.VxlanLink{
+							SrcAddress: "10.0.0.2", DstAddress: "10.0.0.1", Vni: 10,
+							Gpe: &vpp_interfaces.VxlanLink_Gpe{},
+						}},
+					}, nil),
+					testIface(&vpp_interfaces.Interface{
+						Name: "vxlan1",
+						Type: vpp_interfaces.Interface_VXLAN_TUNNEL,
+						Link: &vpp_interfaces.Interface_Vxlan{Vxlan: &vpp_interfaces.VxlanLink{
+							SrcAddress: "10.0.0.2", DstAddress: "10.0.0.1", Vni: 10,
+						}},
+					}, nil),
+				}),
+			},
+			want: []string{
+				`vxlan-gpe-tun "instance::a/vxlan0" -> "instance::b/vxlan0"`,
+				`vxlan-gpe-tun "instance::b/vxlan0" -> "instance::a/vxlan0"`,
+			},
+		},
+		{
+			name: "gtpu tunnel",
+			instances: []*vpp.Instance{
+				testInstance("a", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{
+						Name: "gtpu0",
+						Type: vpp_interfaces.Interface_GTPU_TUNNEL,
+						Link: &vpp_interfaces.Interface_Gtpu{Gtpu: &vpp_interfaces.GtpuLink{
+							SrcAddr: "10.0.0.1", DstAddr: "10.0.0.2", Teid: 100, RemoteTeid: 200,
+						}},
+					}, nil),
+				}),
+				testInstance("b", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{
+						Name: "gtpu0",
+						Type: vpp_interfaces.Interface_GTPU_TUNNEL,
+						Link: &vpp_interfaces.Interface_Gtpu{Gtpu: &vpp_interfaces.GtpuLink{
+							SrcAddr: "10.0.0.2", DstAddr: "10.0.0.1", Teid: 200, RemoteTeid: 100,
+						}},
+					}, nil),
+					testIface(&vpp_interfaces.Interface{
+						Name: "gtpu1",
+						Type: vpp_interfaces.Interface_GTPU_TUNNEL,
+						Link: &vpp_interfaces.Interface_Gtpu{Gtpu: &vpp_interfaces.GtpuLink{
+							SrcAddr: "10.0.0.2", DstAddr: "10.0.0.1", Teid: 300, RemoteTeid: 100,
+						}},
+					}, nil),
+				}),
+			},
+			want: []string{
+				`gtpu-tun "instance::a/gtpu0" -> "instance::b/gtpu0"`,
+				`gtpu-tun "instance::b/gtpu0" -> "instance::a/gtpu0"`,
+			},
+		},
+		{
+			name: "wireguard",
+			instances: []*vpp.Instance{
+				testInstance("a", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{
+						Name: "wg0",
+						Type: vpp_interfaces.Interface_WIREGUARD_TUNNEL,
+						Link: &vpp_interfaces.Interface_Wireguard{Wireguard: &vpp_interfaces.WireguardLink{SrcAddr: "10.0.0.1", Port: 51820}},
+					}, map[string]interface{}{"PublicKey": "keyA"}),
+				}, &vpp_wg.Peer{WgIfName: "wg0", PublicKey: "keyB"}),
+				testInstance("b", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{
+						Name: "wg0",
+						Type: vpp_interfaces.Interface_WIREGUARD_TUNNEL,
+						Link: &vpp_interfaces.Interface_Wireguard{Wireguard: &vpp_interfaces.WireguardLink{SrcAddr: "10.0.0.2", Port: 51820}},
+					}, map[string]interface{}{"PublicKey": "keyB"}),
+				}, &vpp_wg.Peer{WgIfName: "wg0", PublicKey: "keyA"}),
+				testInstance("c", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{
+						Name: "wg1",
+						Type: vpp_interfaces.Interface_WIREGUARD_TUNNEL,
+						Link: &vpp_interfaces.Interface_Wireguard{Wireguard: &vpp_interfaces.WireguardLink{SrcAddr: "10.0.0.3", Port: 51820}},
+					}, nil),
+				}, &vpp_wg.Peer{WgIfName: "wg1", PublicKey: "keyX", Endpoint: "10.0.0.9", Port: 51820}),
+			},
+			want: []string{
+				`wireguard-peer "instance::a/wg0" -> "instance::b/wg0"`,
+				`wireguard-peer "instance::b/wg0" -> "instance::a/wg0"`,
+			},
+		},
+		{
+			name: "wireguard private key",
+			instances: []*vpp.Instance{
+				testInstance("a", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{
+						Name: "wg0",
+						Type: vpp_interfaces.Interface_WIREGUARD_TUNNEL,
+						Link: &vpp_interfaces.Interface_Wireguard{Wireguard: &vpp_interfaces.WireguardLink{
+							PrivateKey: "BwgJCgsMDQ4PEBESExQVFhcYGRobHB0eHyAhIiMkJSY=", SrcAddr: "10.0.0.1", Port: 51820,
+						}},
+					}, nil),
+				}, &vpp_wg.Peer{WgIfName: "wg0", PublicKey: "AGzgpp14hdodUv2Cr78IsVTqa/0XsVIo3UKQ94jygyk="}),
+				testInstance("b", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{
+						Name: "wg0",
+						Type: vpp_interfaces.Interface_WIREGUARD_TUNNEL,
+						Link: &vpp_interfaces.Interface_Wireguard{Wireguard: &vpp_interfaces.WireguardLink{
+							PrivateKey: "DhASFBYYGhweICIkJigqLC4wMjQ2ODo8PkBCREZISkw=", SrcAddr: "10.0.0.2", Port: 51820,
+						}},
+					}, nil),
+				}, &vpp_wg.Peer{WgIfName: "wg0", PublicKey: "B7jFQkh2hqeDAYVfy20/aoqRHNfxmDqbRNydzSKDnSM="}),
+			},
+			want: []string{
+				`wireguard-peer "instance::a/wg0" -> "instance::b/wg0"`,
+				`wireguard-peer "instance::b/wg0" -> "instance::a/wg0"`,
+			},
+		},
+		{
+			name: "bond members and sub-interface",
+			instances: []*vpp.Instance{
+				testInstance("a", []agent.VppInterface{
+					testIface(&vpp_interfaces.Interface{
+						Name: "bond0",
+						Type: vpp_interfaces.Interface_BOND_INTERFACE,
+						Link: &vpp_interfaces.Interface_Bond{Bond: &vpp_interfaces.BondLink{
+							BondedInterfaces: []*vpp_interfaces.BondLink_BondedInterface{
+								{Name: "eth0"}, {Name: "eth1"}, {Name: "missing"},
+							},
+						}},
+					}, nil),
+					testIface(&vpp_interfaces.Interface{Name: "eth0", Type: vpp_interfaces.Interface_DPDK}, nil),
+					testIface(&vpp_interfaces.Interface{Name: "eth1", Type: vpp_interfaces.Interface_DPDK}, nil),
+					testIface(&vpp_interfaces.Interface{
+						Name: "bond0.10",
+						Type: vpp_interfaces.Interface_SUB_INTERFACE,
+						Link: &vpp_interfaces.Interface_Sub{Sub: &vpp_interfaces.SubInterface{ParentName: "bond0", SubId: 10}},
+					}, nil),
+				}),
+			},
+			want: []string{
+				`bond-member "instance::a/bond0" -> "instance::a/eth0"`,
+				`bond-member "instance::a/bond0" -> "instance::a/eth1"`,
+				`sub-parent "instance::a/bond0.10" -> "instance::a/bond0"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := Build(tt.instances)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := connStrings(info)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Build() connections\n got: %q\nwant: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildUnpaired(t *testing.T) {
+	vxlan := func(name, src, dst string, vni uint32) agent.VppInterface {
+		return testIface(&vpp_interfaces.Interface{
+			Name: name,
+			Type: vpp_interfaces.Interface_VXLAN_TUNNEL,
+			Mtu:  1450,
+			Link: &vpp_interfaces.Interface_Vxlan{Vxlan: &vpp_interfaces.VxlanLink{SrcAddress: src, DstAddress: dst, Vni: vni}},
+		}, nil)
+	}
+	instances := []*vpp.Instance{
+		testInstance("a", []agent.VppInterface{vxlan("vxlan0", "10.0.0.1", "10.0.0.2", 10)}),
+		testInstance("b", []agent.VppInterface{vxlan("vxlan0", "10.0.0.2", "10.0.0.1", 11)}),
+		// instance without config is skipped
+		vpp.NewOfflineInstance("c", nil, nil),
+	}
+
+	info, err := Build(instances)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Connections) != 0 {
+		t.Errorf("expected no connections, got %v", connStrings(info))
+	}
+	if len(info.Unpaired) != 2 {
+		t.Fatalf("expected 2 unpaired endpoints, got %+v", info.Unpaired)
+	}
+	want := map[string]string{
+		"type":       "vxlan-tun",
+		"peer":       "10.0.0.2",
+		"state":      "up",
+		"mtu":        "1450",
+		"tunnel-src": "10.0.0.1",
+		"tunnel-dst": "10.0.0.2",
+		"vni":        "10",
+	}
+	if got := info.Unpaired[0].Metadata; !reflect.DeepEqual(got, want) {
+		t.Errorf("unpaired metadata\n got: %v\nwant: %v", got, want)
+	}
+}
+
+func TestBuildUnknownInode(t *testing.T) {
+	vhost := func(name string, inode interface{}) agent.VppInterface {
+		metadata := map[string]interface{}{"VhostSocket": "/run/vhost.sock"}
+		if inode != nil {
+			metadata["inode"] = inode
+		}
+		return testIface(&vpp_interfaces.Interface{Name: name}, metadata)
+	}
+	memif := func(name string, inode interface{}) agent.VppInterface {
+		return testIface(&vpp_interfaces.Interface{
+			Name: name,
+			Type: vpp_interfaces.Interface_MEMIF,
+			Link: &vpp_interfaces.Interface_Memif{Memif: &vpp_interfaces.MemifLink{Id: 1, SocketFilename: "/run/memif.sock"}},
+		}, map[string]interface{}{"inode": inode})
+	}
+	instances := []*vpp.Instance{
+		testInstance("a", []agent.VppInterface{vhost("VirtualEthernet0/0/0", nil), memif("memif1", 0)}),
+		testInstance("b", []agent.VppInterface{vhost("VirtualEthernet0/0/1", nil), memif("memif1", 0)}),
+		testInstance("c", []agent.VppInterface{vhost("VirtualEthernet0/0/2", 0)}),
+	}
+
+	info, err := Build(instances)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, conn := range info.Connections {
+		if conn.Destination.Kind != FileEndpoint {
+			t.Errorf("expected no connection between interfaces with unknown inode, got %v", conn)
+		}
+	}
+	if len(info.Unpaired) != 5 {
+		t.Errorf("expected 5 unpaired endpoints, got %+v", info.Unpaired)
+	}
+}
+
+func TestBuildNetworks(t *testing.T) {
+	config := &agent.Config{}
+	config.VPP.Interfaces = []agent.VppInterface{
+		testIface(&vpp_interfaces.Interface{Name: "local0"}, nil),
+		testIface(&vpp_interfaces.Interface{Name: "tap1", Type: vpp_interfaces.Interface_TAP},
+			map[string]interface{}{"SwIfIndex": 1, "InternalName": "tap0"}),
+	}
+	config.Linux.Interfaces = []agent.LinuxInterface{
+		{Value: &linux_interfaces.Interface{
+			Name:       "tap1",
+			Type:       linux_interfaces.Interface_TAP_TO_VPP,
+			Enabled:    true,
+			HostIfName: "eth0",
+			Namespace:  &linux_namespace.NetNamespace{Type: linux_namespace.NetNamespace_MICROSERVICE, Reference: "app"},
+			Link:       &linux_interfaces.Interface_Tap{Tap: &linux_interfaces.TapLink{VppTapIfName: "tap1"}},
+		}},
+	}
+	instance := vpp.NewOfflineInstance("a", map[string]string{"cluster": "c1", "node": "n1"}, config)
+
+	info, err := Build([]*vpp.Instance{instance})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, network := range info.Networks {
+		if network.Cluster != "c1" || network.Node != "n1" {
+			t.Errorf("network %v: unexpected cluster/node %q/%q", network.Label(), network.Cluster, network.Node)
+		}
+		for _, e := range network.Endpoints {
+			got = append(got, network.Label()+" "+e.Interface+" "+e.Metadata["internal"])
+		}
+	}
+	want := []string{"VPP tap1 tap0", "NS: app tap1 eth0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Build() networks\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestTunnelPairsWith(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b tunnelInfo
+		want bool
+	}{
+		{"swapped addresses", tunnelInfo{Type: "gre-tun", Src: "a", Dst: "b"}, tunnelInfo{Type: "gre-tun", Src: "b", Dst: "a"}, true},
+		{"same addresses", tunnelInfo{Type: "gre-tun", Src: "a", Dst: "b"}, tunnelInfo{Type: "gre-tun", Src: "a", Dst: "b"}, false},
+		{"different type", tunnelInfo{Type: "gre-tun", Src: "a", Dst: "b"}, tunnelInfo{Type: "ipip-tun", Src: "b", Dst: "a"}, false},
+		{"different vni", tunnelInfo{Type: "vxlan-tun", Src: "a", Dst: "b", VNI: 1}, tunnelInfo{Type: "vxlan-tun", Src: "b", Dst: "a", VNI: 2}, false},
+		{"teid without remote", tunnelInfo{Type: "gtpu-tun", Src: "a", Dst: "b", Teid: 1}, tunnelInfo{Type: "gtpu-tun", Src: "b", Dst: "a", Teid: 1}, true},
+		{"teid crossed", tunnelInfo{Type: "gtpu-tun", Src: "a", Dst: "b", Teid: 1, RemoteTeid: 2}, tunnelInfo{Type: "gtpu-tun", Src: "b", Dst: "a", Teid: 2, RemoteTeid: 1}, true},
+		{"teid not crossed", tunnelInfo{Type: "gtpu-tun", Src: "a", Dst: "b", Teid: 1, RemoteTeid: 2}, tunnelInfo{Type: "gtpu-tun", Src: "b", Dst: "a", Teid: 1, RemoteTeid: 2}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.pairsWith(&tt.b); got != tt.want {
+				t.Errorf("pairsWith() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
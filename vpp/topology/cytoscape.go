@@ -0,0 +1,83 @@
+package topology
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// cyElement is an element of Cytoscape.js graph.
+type cyElement struct {
+	Data cyData `json:"data"`
+}
+
+type cyData struct {
+	ID     string `json:"id"`
+	Label  string `json:"label,omitempty"`
+	Parent string `json:"parent,omitempty"`
+	Kind   string `json:"kind,omitempty"`
+	State  string `json:"state,omitempty"`
+	Source string `json:"source,omitempty"`
+	Target string `json:"target,omitempty"`
+	Type   string `json:"type,omitempty"`
+}
+
+type cyGraph struct {
+	Elements struct {
+		Nodes []cyElement `json:"nodes"`
+		Edges []cyElement `json:"edges"`
+	} `json:"elements"`
+}
+
+// writeCytoscape writes graph in Cytoscape.js JSON format. Groups are
+// written as compound nodes.
+func writeCytoscape(w io.Writer, g *graph) error {
+	var cy cyGraph
+	cy.Elements.Nodes = []cyElement{}
+	cy.Elements.Edges = []cyElement{}
+
+	addNode := func(n *graphNode, parent string) {
+		cy.Elements.Nodes = append(cy.Elements.Nodes, cyElement{Data: cyData{
+			ID:     n.ID,
+			Label:  n.Label,
+			Parent: parent,
+			Kind:   string(n.Kind),
+			State:  n.State,
+		}})
+	}
+	g.walkGroups(func(group, parent *graphGroup) {
+		data := cyData{
+			ID:    group.ID,
+			Label: group.Label,
+			Kind:  string(group.Kind),
+		}
+		if parent != nil {
+			data.Parent = parent.ID
+		}
+		cy.Elements.Nodes = append(cy.Elements.Nodes, cyElement{Data: data})
+		for _, n := range group.Nodes {
+			addNode(n, group.ID)
+		}
+	})
+	for _, n := range g.Nodes {
+		addNode(n, "")
+	}
+	for i, e := range g.Edges {
+		label := e.Type
+		if e.Label != "" {
+			label = e.Label
+		}
+		cy.Elements.Edges = append(cy.Elements.Edges, cyElement{Data: cyData{
+			ID:     fmt.Sprintf("e%d", i),
+			Label:  label,
+			State:  e.State,
+			Source: e.Source,
+			Target: e.Target,
+			Type:   e.Type,
+		}})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(cy)
+}
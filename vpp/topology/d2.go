@@ -0,0 +1,94 @@
+package topology
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"go.ligato.io/vpp-probe/pkg/strutil"
+)
+
+// writeD2 writes graph in D2 language. Groups are written as containers
+// and edges refer to nodes by their full path.
+func writeD2(w io.Writer, g *graph) error {
+	paths := map[string]string{}
+	var nodeIdx int
+
+	fmt.Fprintln(w, "direction: right")
+
+	writeNode := func(w io.Writer, n *graphNode, parent string) {
+		key := fmt.Sprintf("n%d", nodeIdx)
+		nodeIdx++
+		paths[n.ID] = key
+		if parent != "" {
+			paths[n.ID] = parent + "." + key
+		}
+		fmt.Fprintf(w, "%s: %s {\n", key, d2String(n.Label))
+		{
+			w := strutil.IndentedWriter(w)
+			if n.Kind == fileNode {
+				fmt.Fprintln(w, "shape: cylinder")
+			}
+			fmt.Fprintf(w, "style.fill: %s\n", d2String(strings.ToLower(d2NodeColor(n))))
+		}
+		fmt.Fprintln(w, "}")
+	}
+
+	var groupIdx int
+	var writeGroup func(w io.Writer, group *graphGroup, parent string)
+	writeGroup = func(w io.Writer, group *graphGroup, parent string) {
+		key := fmt.Sprintf("g%d", groupIdx)
+		groupIdx++
+		path := key
+		if parent != "" {
+			path = parent + "." + key
+		}
+		fmt.Fprintf(w, "%s: %s {\n", key, d2String(group.Label))
+		{
+			w := strutil.IndentedWriter(w)
+			for _, n := range group.Nodes {
+				writeNode(w, n, path)
+			}
+			for _, sub := range group.Groups {
+				writeGroup(w, sub, path)
+			}
+		}
+		fmt.Fprintln(w, "}")
+	}
+	for _, group := range g.Groups {
+		writeGroup(w, group, "")
+	}
+	for _, n := range g.Nodes {
+		writeNode(w, n, "")
+	}
+
+	for _, e := range g.Edges {
+		label := e.Type
+		if e.Label != "" {
+			label = e.Label
+		}
+		fmt.Fprintf(w, "%s -> %s: %s", paths[e.Source], paths[e.Target], d2String(label))
+		if e.IsDown() {
+			fmt.Fprint(w, " {style.stroke: orangered}")
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+func d2NodeColor(n *graphNode) string {
+	switch {
+	case n.IsDown():
+		return vppIfaceFillColorDown
+	case n.Kind == linuxNode:
+		return linuxIfaceFillColor
+	case n.Kind == fileNode:
+		return hostBgColor
+	}
+	return vppIfaceFillColor
+}
+
+func d2String(s string) string {
+	return strconv.Quote(s)
+}
@@ -0,0 +1,278 @@
+package topology
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"go.ligato.io/vpp-probe/pkg/strutil"
+)
+
+const (
+	diffAddedColor   = "forestgreen"
+	diffRemovedColor = "red"
+	diffChangedColor = "orange"
+)
+
+// StateChange is a change of endpoint or connection state.
+type StateChange struct {
+	// Name is endpoint in format "instance/interface" or connection.
+	Name   string
+	Before string
+	After  string
+}
+
+// Diff contains differences between two topology snapshots.
+type Diff struct {
+	AddedInstances     []string      `json:",omitempty"`
+	RemovedInstances   []string      `json:",omitempty"`
+	AddedConnections   []Connection  `json:",omitempty"`
+	RemovedConnections []Connection  `json:",omitempty"`
+	StateChanges       []StateChange `json:",omitempty"`
+
+	// unchanged are connections found in both snapshots
+	unchanged []Connection
+}
+
+// IsEmpty returns true if there are no differences.
+func (d *Diff) IsEmpty() bool {
+	return len(d.AddedInstances) == 0 && len(d.RemovedInstances) == 0 &&
+		len(d.AddedConnections) == 0 && len(d.RemovedConnections) == 0 &&
+		len(d.StateChanges) == 0
+}
+
+// DiffSnapshots compares two topology snapshots.
+func DiffSnapshots(before, after *Snapshot) *Diff {
+	diff := &Diff{}
+
+	beforeInst := stringSet(before.Instances)
+	afterInst := stringSet(after.Instances)
+	for _, id := range after.Instances {
+		if !beforeInst[id] {
+			diff.AddedInstances = append(diff.AddedInstances, id)
+		}
+	}
+	for _, id := range before.Instances {
+		if !afterInst[id] {
+			diff.RemovedInstances = append(diff.RemovedInstances, id)
+		}
+	}
+
+	beforeConns := connectionsByKey(before.Topology)
+	afterConns := connectionsByKey(after.Topology)
+	for _, key := range sortedConnKeys(afterConns) {
+		conn, ok := beforeConns[key]
+		if !ok {
+			diff.AddedConnections = append(diff.AddedConnections, afterConns[key])
+			continue
+		}
+		diff.unchanged = append(diff.unchanged, afterConns[key])
+		if s1, s2 := conn.Metadata["state"], afterConns[key].Metadata["state"]; s1 != s2 {
+			diff.StateChanges = append(diff.StateChanges, StateChange{
+				Name:   connName(conn),
+				Before: stateOrUp(s1),
+				After:  stateOrUp(s2),
+			})
+		}
+	}
+	for _, key := range sortedConnKeys(beforeConns) {
+		if _, ok := afterConns[key]; !ok {
+			diff.RemovedConnections = append(diff.RemovedConnections, beforeConns[key])
+		}
+	}
+
+	beforeStates := endpointStates(before.Topology)
+	afterStates := endpointStates(after.Topology)
+	for _, name := range sortedStateKeys(afterStates) {
+		s1, ok := beforeStates[name]
+		if ok && s1 != afterStates[name] {
+			diff.StateChanges = append(diff.StateChanges, StateChange{
+				Name:   name,
+				Before: s1,
+				After:  afterStates[name],
+			})
+		}
+	}
+
+	return diff
+}
+
+// diffConnKey returns key identifying directed connection of a type.
+// Connections to socket files are identified by socket path instead of
+// inode, which changes whenever the socket is re-created (e.g. pod restart).
+func diffConnKey(conn Connection) string {
+	if conn.Destination.Kind == FileEndpoint {
+		return fmt.Sprintf("%s->socket:%s|%s", endpointID(conn.Source), conn.Metadata["label"], conn.Metadata["type"])
+	}
+	return fmt.Sprintf("%s->%s|%s", endpointID(conn.Source), endpointID(conn.Destination), conn.Metadata["type"])
+}
+
+func connectionsByKey(info *Info) map[string]Connection {
+	conns := map[string]Connection{}
+	if info == nil {
+		return conns
+	}
+	for _, conn := range info.Connections {
+		conns[diffConnKey(conn)] = conn
+	}
+	return conns
+}
+
+// endpointStates returns states of interface endpoints by endpoint name.
+func endpointStates(info *Info) map[string]string {
+	states := map[string]string{}
+	if info == nil {
+		return states
+	}
+	for _, conn := range info.Connections {
+		for _, e := range []Endpoint{conn.Source, conn.Destination} {
+			if e.Kind == FileEndpoint {
+				continue
+			}
+			if state := e.Metadata["state"]; state != "" {
+				states[endpointName(e)] = state
+			}
+		}
+	}
+	return states
+}
+
+func stateOrUp(state string) string {
+	if state == "" {
+		return "up"
+	}
+	return state
+}
+
+func stringSet(list []string) map[string]bool {
+	set := make(map[string]bool, len(list))
+	for _, s := range list {
+		set[s] = true
+	}
+	return set
+}
+
+func sortedConnKeys(m map[string]Connection) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedStateKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// connName returns name of connection used in state changes.
+func connName(conn Connection) string {
+	return fmt.Sprintf("%s -> %s (%s)", endpointName(conn.Source), endpointName(conn.Destination), conn.Metadata["type"])
+}
+
+// PrintDiffDot prints topology diff in Graphviz format. Added connections
+// are green, removed connections are red and connections with changed
+// state are orange.
+func PrintDiffDot(w io.Writer, diff *Diff) error {
+	changed := map[string]bool{}
+	for _, c := range diff.StateChanges {
+		changed[c.Name] = true
+	}
+
+	type diffEdge struct {
+		conn  Connection
+		color string
+	}
+	var edges []diffEdge
+	for _, c := range diff.unchanged {
+		color := "black"
+		if changed[connName(c)] {
+			color = diffChangedColor
+		}
+		edges = append(edges, diffEdge{c, color})
+	}
+	for _, c := range diff.AddedConnections {
+		edges = append(edges, diffEdge{c, diffAddedColor})
+	}
+	for _, c := range diff.RemovedConnections {
+		edges = append(edges, diffEdge{c, diffRemovedColor})
+	}
+
+	// group endpoints by instance
+	instances := map[string]map[string]Endpoint{}
+	var instanceIDs []string
+	for _, e := range edges {
+		for _, ep := range []Endpoint{e.conn.Source, e.conn.Destination} {
+			if instances[ep.Instance] == nil {
+				instances[ep.Instance] = map[string]Endpoint{}
+				instanceIDs = append(instanceIDs, ep.Instance)
+			}
+			instances[ep.Instance][endpointID(ep)] = ep
+		}
+	}
+	sort.Strings(instanceIDs)
+	added := stringSet(diff.AddedInstances)
+	removed := stringSet(diff.RemovedInstances)
+
+	fprintSection(w, "digraph G", func(w io.Writer) {
+		fmt.Fprintf(w, "rankdir=%s;\n", "LR")
+		fmt.Fprintf(w, "bgcolor=%s;\n", graphBgColor)
+
+		for _, instance := range instanceIDs {
+			endpoints := instances[instance]
+			ids := make([]string, 0, len(endpoints))
+			for id := range endpoints {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			fmt.Fprintln(w)
+			if instance != "" {
+				fmt.Fprintf(w, "subgraph %q {\n", "cluster_"+instance)
+			}
+			{
+				w := strutil.IndentedWriter(w)
+				if instance != "" {
+					fmt.Fprintf(w, "label=%q;\n", strings.TrimPrefix(instance, "instance::"))
+					bgcolor := hostBgColor
+					if added[instance] {
+						bgcolor = "PaleGreen"
+					} else if removed[instance] {
+						bgcolor = "MistyRose"
+					}
+					fmt.Fprintf(w, "bgcolor=%s;\n", bgcolor)
+				}
+				for _, id := range ids {
+					ep := endpoints[id]
+					fillcolor := vppIfaceFillColor
+					if ep.Type == KernelNetwork {
+						fillcolor = linuxIfaceFillColor
+					}
+					if isDownState(ep.Metadata["state"]) {
+						fillcolor = vppIfaceFillColorDown
+					}
+					fmt.Fprintf(w, "%q [label=%q,style=%q,fillcolor=%s];\n", id, ep.Interface, "solid,filled", fillcolor)
+				}
+			}
+			if instance != "" {
+				fmt.Fprintln(w, "}")
+			}
+		}
+		fmt.Fprintln(w)
+
+		for _, e := range edges {
+			style := "solid"
+			if e.color == diffRemovedColor {
+				style = "dashed"
+			}
+			fmt.Fprintf(w, "%q -> %q [color=%q,style=%q,label=%q];\n",
+				endpointID(e.conn.Source), endpointID(e.conn.Destination), e.color, style, e.conn.Metadata["type"])
+		}
+	})
+	return nil
+}
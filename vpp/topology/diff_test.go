@@ -0,0 +1,58 @@
+package topology
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	vppA, vppB, vppC := testNetwork("a"), testNetwork("b"), testNetwork("c")
+	socket := func(inode string) Endpoint {
+		return Endpoint{Interface: "inode " + inode, Kind: FileEndpoint}
+	}
+
+	before := &Snapshot{
+		Instances: []string{"instance::a", "instance::b"},
+		Topology: &Info{Connections: []Connection{
+			testConnection("memif-sock", testEndpoint(vppA, "memif1", "state", "up"), testEndpoint(vppB, "memif1")),
+			testConnection("vxlan-tun", testEndpoint(vppA, "vxlan0"), testEndpoint(vppB, "vxlan0")),
+			testConnection("vhost-sock", testEndpoint(vppA, "vhost1"), socket("100"), "state", "down", "label", "/run/vhost1.sock"),
+		}},
+	}
+	after := &Snapshot{
+		Instances: []string{"instance::a", "instance::c"},
+		Topology: &Info{Connections: []Connection{
+			testConnection("memif-sock", testEndpoint(vppA, "memif1", "state", "link down"), testEndpoint(vppB, "memif1"), "state", "down"),
+			testConnection("memif-sock", testEndpoint(vppA, "memif2"), testEndpoint(vppC, "memif1")),
+			testConnection("vhost-sock", testEndpoint(vppA, "vhost1"), socket("200"), "state", "down", "label", "/run/vhost1.sock"),
+		}},
+	}
+
+	diff := DiffSnapshots(before, after)
+
+	if want := []string{"instance::c"}; !reflect.DeepEqual(diff.AddedInstances, want) {
+		t.Errorf("added instances = %v, want %v", diff.AddedInstances, want)
+	}
+	if want := []string{"instance::b"}; !reflect.DeepEqual(diff.RemovedInstances, want) {
+		t.Errorf("removed instances = %v, want %v", diff.RemovedInstances, want)
+	}
+	if len(diff.AddedConnections) != 1 || diff.AddedConnections[0].Destination.Instance != "instance::c" {
+		t.Errorf("added connections = %+v", diff.AddedConnections)
+	}
+	if len(diff.RemovedConnections) != 1 || diff.RemovedConnections[0].Metadata["type"] != "vxlan-tun" {
+		t.Errorf("removed connections = %+v", diff.RemovedConnections)
+	}
+	wantChanges := []StateChange{
+		{Name: "a/memif1 -> b/memif1 (memif-sock)", Before: "up", After: "down"},
+		{Name: "a/memif1", Before: "up", After: "link down"},
+	}
+	if !reflect.DeepEqual(diff.StateChanges, wantChanges) {
+		t.Errorf("state changes\n got: %+v\nwant: %+v", diff.StateChanges, wantChanges)
+	}
+	if diff.IsEmpty() {
+		t.Errorf("expected diff not to be empty")
+	}
+	if !DiffSnapshots(before, before).IsEmpty() {
+		t.Errorf("expected diff of same snapshot to be empty")
+	}
+}
@@ -0,0 +1,132 @@
+package topology
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"go.ligato.io/vpp-probe/vpp"
+)
+
+// PrintTopologyMermaid prints topology as Mermaid flowchart.
+func PrintTopologyMermaid(w io.Writer, instances []*vpp.Instance, info *Info) error {
+	return writeMermaid(w, newGraph(instances, info))
+}
+
+// PrintTopologyD2 prints topology in D2 language.
+func PrintTopologyD2(w io.Writer, instances []*vpp.Instance, info *Info) error {
+	return writeD2(w, newGraph(instances, info))
+}
+
+// PrintTopologyGraphML prints topology in GraphML format.
+func PrintTopologyGraphML(w io.Writer, instances []*vpp.Instance, info *Info) error {
+	return writeGraphML(w, newGraph(instances, info))
+}
+
+// PrintTopologyCytoscape prints topology in Cytoscape.js JSON format.
+func PrintTopologyCytoscape(w io.Writer, instances []*vpp.Instance, info *Info) error {
+	return writeCytoscape(w, newGraph(instances, info))
+}
+
+// newGraph builds graph with instances grouped by cluster and endpoints
+// grouped by networks of instances.
+func newGraph(instances []*vpp.Instance, info *Info) *graph {
+	g := &graph{}
+	nodes := map[string]bool{}
+
+	networks := map[string][]NetworkInfo{}
+	for _, network := range info.Networks {
+		networks[network.Instance] = append(networks[network.Instance], network)
+	}
+
+	clusters := map[string]*graphGroup{}
+	for _, instance := range instances {
+		metadata := instance.Handler().Metadata()
+
+		instGroup := &graphGroup{
+			ID:    instance.ID(),
+			Label: fmt.Sprintf("%v [host]", instance.String()),
+			Kind:  instanceGroup,
+		}
+		if node := metadata["node"]; node != "" {
+			instGroup.Label += fmt.Sprintf("\nnode: %s", node)
+		}
+
+		if c := metadata["cluster"]; c != "" {
+			cluster, ok := clusters[c]
+			if !ok {
+				cluster = &graphGroup{
+					ID:    "cluster_" + c,
+					Label: fmt.Sprintf("%v [cluster]", c),
+					Kind:  clusterGroup,
+				}
+				clusters[c] = cluster
+				g.Groups = append(g.Groups, cluster)
+			}
+			cluster.Groups = append(cluster.Groups, instGroup)
+		} else {
+			g.Groups = append(g.Groups, instGroup)
+		}
+
+		for _, network := range networks[instance.ID()] {
+			netGroup := &graphGroup{
+				ID:    instance.ID() + "_vpp",
+				Label: network.Label(),
+				Kind:  vppGroup,
+			}
+			if network.Type == KernelNetwork {
+				netGroup.ID = fmt.Sprintf("%s_ns_%s", instance.ID(), network.Namespace)
+				netGroup.Kind = namespaceGroup
+			}
+			for _, e := range network.Endpoints {
+				node := newGraphNode(e)
+				netGroup.Nodes = append(netGroup.Nodes, node)
+				nodes[node.ID] = true
+			}
+			instGroup.Groups = append(instGroup.Groups, netGroup)
+		}
+	}
+
+	for _, c := range info.Connections {
+		edge := &graphEdge{
+			Source: endpointID(c.Source),
+			Target: endpointID(c.Destination),
+			Type:   c.Metadata["type"],
+			State:  c.Metadata["state"],
+			Label:  c.Metadata["label"],
+		}
+		for _, e := range []Endpoint{c.Source, c.Destination} {
+			if nodes[endpointID(e)] {
+				continue
+			}
+			node := newGraphNode(e)
+			g.Nodes = append(g.Nodes, node)
+			nodes[node.ID] = true
+		}
+		g.Edges = append(g.Edges, edge)
+	}
+
+	return g
+}
+
+// newGraphNode returns graph node of endpoint.
+func newGraphNode(e Endpoint) *graphNode {
+	node := &graphNode{
+		ID:    endpointID(e),
+		Label: e.Interface,
+		Kind:  vppNode,
+		State: e.Metadata["state"],
+	}
+	if e.Kind == FileEndpoint {
+		node.Kind = fileNode
+	} else if e.Type == KernelNetwork {
+		node.Kind = linuxNode
+	}
+	if internal := e.Metadata["internal"]; internal != "" {
+		node.Label += fmt.Sprintf("\n(%s)", internal)
+	}
+	if ips := e.Metadata["ips"]; ips != "" {
+		node.Label += "\n" + strings.ReplaceAll(ips, ",", "\n")
+	}
+	return node
+}
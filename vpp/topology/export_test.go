@@ -0,0 +1,120 @@
+package topology
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	linux_interfaces "go.ligato.io/vpp-agent/v3/proto/ligato/linux/interfaces"
+	linux_namespace "go.ligato.io/vpp-agent/v3/proto/ligato/linux/namespace"
+	vpp_interfaces "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/interfaces"
+
+	"go.ligato.io/vpp-probe/vpp"
+	"go.ligato.io/vpp-probe/vpp/agent"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// testTopology returns offline instances and topology built from them.
+func testTopology(t *testing.T) ([]*vpp.Instance, *Info) {
+	vppIface := func(idx int, internal string, value *vpp_interfaces.Interface, metadata map[string]interface{}) agent.VppInterface {
+		if metadata == nil {
+			metadata = map[string]interface{}{}
+		}
+		metadata["SwIfIndex"] = idx
+		metadata["InternalName"] = internal
+		if _, ok := metadata["linkstate"]; !ok {
+			metadata["linkstate"] = true
+		}
+		value.Enabled = true
+		return agent.VppInterface{
+			KVData: agent.KVData{Key: vpp_interfaces.InterfaceKey(value.Name), Metadata: metadata},
+			Value:  value,
+		}
+	}
+	memif := func(name string, id uint32, socket string) *vpp_interfaces.Interface {
+		return &vpp_interfaces.Interface{
+			Name: name,
+			Type: vpp_interfaces.Interface_MEMIF,
+			Link: &vpp_interfaces.Interface_Memif{Memif: &vpp_interfaces.MemifLink{Id: id, SocketFilename: socket}},
+		}
+	}
+
+	configA := &agent.Config{}
+	memifA := memif("memif1", 1, "/run/vpp/memif.sock")
+	memifA.IpAddresses = []string{"10.0.0.1/24"}
+	configA.VPP.Interfaces = []agent.VppInterface{
+		vppIface(1, "memif0/1", memifA, map[string]interface{}{"inode": 42}),
+		vppIface(2, "tap0", &vpp_interfaces.Interface{
+			Name: "tap1",
+			Type: vpp_interfaces.Interface_TAP,
+			Link: &vpp_interfaces.Interface_Tap{Tap: &vpp_interfaces.TapLink{}},
+		}, map[string]interface{}{"linkstate": false}),
+	}
+	configA.Linux.Interfaces = []agent.LinuxInterface{{
+		Value: &linux_interfaces.Interface{
+			Name:       "tap1",
+			Type:       linux_interfaces.Interface_TAP_TO_VPP,
+			Enabled:    true,
+			HostIfName: "eth0",
+			Namespace:  &linux_namespace.NetNamespace{Type: linux_namespace.NetNamespace_MICROSERVICE, Reference: "app"},
+			Link:       &linux_interfaces.Interface_Tap{Tap: &linux_interfaces.TapLink{VppTapIfName: "tap1"}},
+		},
+	}}
+
+	configB := &agent.Config{}
+	configB.VPP.Interfaces = []agent.VppInterface{
+		vppIface(1, "memif0/1", memif("memif1", 1, "/run/vpp/memif.sock"), map[string]interface{}{"inode": 42}),
+		vppIface(2, "memif0/2", memif("memif2", 2, "/run/vpp/memif2.sock"), map[string]interface{}{"inode": 43}),
+	}
+
+	instances := []*vpp.Instance{
+		vpp.NewOfflineInstance("a", map[string]string{"name": "vpp-a", "cluster": "c1", "node": "n1"}, configA),
+		vpp.NewOfflineInstance("b", map[string]string{"name": "vpp-b"}, configB),
+	}
+	info, err := Build(instances)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return instances, info
+}
+
+func TestExporters(t *testing.T) {
+	instances, info := testTopology(t)
+
+	tests := []struct {
+		name  string
+		print func(io.Writer, []*vpp.Instance, *Info) error
+	}{
+		{"mermaid", PrintTopologyMermaid},
+		{"d2", PrintTopologyD2},
+		{"graphml", PrintTopologyGraphML},
+		{"cyjs", PrintTopologyCytoscape},
+		{"dot", PrintTopologyDot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.print(&buf, instances, info); err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("testdata", "topology."+tt.name+".golden")
+			if *update {
+				if err := ioutil.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != string(want) {
+				t.Errorf("output differs from %s\n got:\n%s\nwant:\n%s", golden, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package topology
+
+import "strings"
+
+// graphGroupKind is a kind of group of nodes in exported graph.
+type graphGroupKind string
+
+const (
+	clusterGroup   graphGroupKind = "cluster"
+	instanceGroup  graphGroupKind = "instance"
+	vppGroup       graphGroupKind = "vpp"
+	namespaceGroup graphGroupKind = "namespace"
+)
+
+// graphNodeKind is a kind of node in exported graph.
+type graphNodeKind string
+
+const (
+	vppNode   graphNodeKind = "vpp"
+	linuxNode graphNodeKind = "linux"
+	fileNode  graphNodeKind = "file"
+)
+
+// graph is a format independent representation of topology used
+// by exporters.
+type graph struct {
+	Groups []*graphGroup
+	// Nodes are nodes that are not part of any group.
+	Nodes []*graphNode
+	Edges []*graphEdge
+}
+
+type graphGroup struct {
+	ID     string
+	Label  string
+	Kind   graphGroupKind
+	Groups []*graphGroup
+	Nodes  []*graphNode
+}
+
+type graphNode struct {
+	ID    string
+	Label string
+	Kind  graphNodeKind
+	State string
+}
+
+// IsDown returns true if node state is down.
+func (n *graphNode) IsDown() bool {
+	return isDownState(n.State)
+}
+
+type graphEdge struct {
+	Source string
+	Target string
+	Type   string
+	State  string
+	Label  string
+}
+
+// IsDown returns true if edge state is down.
+func (e *graphEdge) IsDown() bool {
+	return isDownState(e.State)
+}
+
+// walkGroups calls fn for every group and its parent in depth-first order.
+func (g *graph) walkGroups(fn func(group, parent *graphGroup)) {
+	var walk func(groups []*graphGroup, parent *graphGroup)
+	walk = func(groups []*graphGroup, parent *graphGroup) {
+		for _, group := range groups {
+			fn(group, parent)
+			walk(group.Groups, group)
+		}
+	}
+	walk(g.Groups, nil)
+}
+
+func isDownState(state string) bool {
+	return strings.Contains(state, "down")
+}
@@ -0,0 +1,104 @@
+package topology
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"go.ligato.io/vpp-probe/pkg/strutil"
+)
+
+// graphMLKeys are attributes defined for nodes and edges.
+var graphMLKeys = []struct {
+	ID, For, Name string
+}{
+	{"label", "node", "label"},
+	{"kind", "node", "kind"},
+	{"state", "node", "state"},
+	{"type", "edge", "type"},
+	{"estate", "edge", "state"},
+	{"elabel", "edge", "label"},
+}
+
+// writeGraphML writes graph in GraphML format. Groups are written as nodes
+// with nested graphs.
+func writeGraphML(w io.Writer, g *graph) error {
+	fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?>`)
+	fmt.Fprintln(w, `<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`)
+	{
+		w := strutil.IndentedWriter(w)
+
+		for _, key := range graphMLKeys {
+			fmt.Fprintf(w, "<key id=%q for=%q attr.name=%q attr.type=\"string\"/>\n", key.ID, key.For, key.Name)
+		}
+
+		writeData := func(w io.Writer, key, value string) {
+			if value != "" {
+				fmt.Fprintf(w, "<data key=%q>%s</data>\n", key, xmlEscape(value))
+			}
+		}
+		writeNode := func(w io.Writer, n *graphNode) {
+			fmt.Fprintf(w, "<node id=\"%s\">\n", xmlEscape(n.ID))
+			{
+				w := strutil.IndentedWriter(w)
+				writeData(w, "label", n.Label)
+				writeData(w, "kind", string(n.Kind))
+				writeData(w, "state", n.State)
+			}
+			fmt.Fprintln(w, "</node>")
+		}
+
+		var writeGroup func(w io.Writer, group *graphGroup)
+		writeGroup = func(w io.Writer, group *graphGroup) {
+			fmt.Fprintf(w, "<node id=\"%s\">\n", xmlEscape(group.ID))
+			{
+				w := strutil.IndentedWriter(w)
+				writeData(w, "label", group.Label)
+				writeData(w, "kind", string(group.Kind))
+				fmt.Fprintf(w, "<graph id=\"%s:\" edgedefault=\"directed\">\n", xmlEscape(group.ID))
+				{
+					w := strutil.IndentedWriter(w)
+					for _, n := range group.Nodes {
+						writeNode(w, n)
+					}
+					for _, sub := range group.Groups {
+						writeGroup(w, sub)
+					}
+				}
+				fmt.Fprintln(w, "</graph>")
+			}
+			fmt.Fprintln(w, "</node>")
+		}
+
+		fmt.Fprintln(w, `<graph id="topology" edgedefault="directed">`)
+		{
+			w := strutil.IndentedWriter(w)
+			for _, group := range g.Groups {
+				writeGroup(w, group)
+			}
+			for _, n := range g.Nodes {
+				writeNode(w, n)
+			}
+			for i, e := range g.Edges {
+				fmt.Fprintf(w, "<edge id=\"e%d\" source=\"%s\" target=\"%s\">\n", i, xmlEscape(e.Source), xmlEscape(e.Target))
+				{
+					w := strutil.IndentedWriter(w)
+					writeData(w, "type", e.Type)
+					writeData(w, "estate", e.State)
+					writeData(w, "elabel", e.Label)
+				}
+				fmt.Fprintln(w, "</edge>")
+			}
+		}
+		fmt.Fprintln(w, "</graph>")
+	}
+	fmt.Fprintln(w, "</graphml>")
+	return nil
+}
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,88 @@
+package topology
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"go.ligato.io/vpp-probe/pkg/strutil"
+)
+
+// writeMermaid writes graph as Mermaid flowchart. Node IDs are replaced
+// with short IDs since Mermaid allows only limited set of characters.
+func writeMermaid(w io.Writer, g *graph) error {
+	ids := map[string]string{}
+	nodeID := func(id string) string {
+		if _, ok := ids[id]; !ok {
+			ids[id] = fmt.Sprintf("n%d", len(ids))
+		}
+		return ids[id]
+	}
+
+	fmt.Fprintln(w, "flowchart LR")
+	{
+		w := strutil.IndentedWriter(w)
+
+		var downNodes []string
+		writeNode := func(w io.Writer, n *graphNode) {
+			id := nodeID(n.ID)
+			shape := `["%s"]`
+			if n.Kind == fileNode {
+				shape = `[("%s")]`
+			}
+			fmt.Fprintf(w, "%s"+shape+"\n", id, mermaidLabel(n.Label))
+			if n.IsDown() {
+				downNodes = append(downNodes, id)
+			}
+		}
+
+		var groupIdx int
+		var writeGroup func(w io.Writer, group *graphGroup)
+		writeGroup = func(w io.Writer, group *graphGroup) {
+			fmt.Fprintf(w, "subgraph g%d[\"%s\"]\n", groupIdx, mermaidLabel(group.Label))
+			groupIdx++
+			{
+				w := strutil.IndentedWriter(w)
+				for _, n := range group.Nodes {
+					writeNode(w, n)
+				}
+				for _, sub := range group.Groups {
+					writeGroup(w, sub)
+				}
+			}
+			fmt.Fprintln(w, "end")
+		}
+		for _, group := range g.Groups {
+			writeGroup(w, group)
+		}
+		for _, n := range g.Nodes {
+			writeNode(w, n)
+		}
+
+		var downEdges []string
+		for i, e := range g.Edges {
+			label := e.Type
+			if e.Label != "" {
+				label = e.Label
+			}
+			fmt.Fprintf(w, "%s -- \"%s\" --> %s\n", nodeID(e.Source), mermaidLabel(label), nodeID(e.Target))
+			if e.IsDown() {
+				downEdges = append(downEdges, fmt.Sprint(i))
+			}
+		}
+
+		if len(downNodes) > 0 {
+			fmt.Fprintf(w, "classDef down fill:%s\n", strings.ToLower(vppIfaceFillColorDown))
+			fmt.Fprintf(w, "class %s down\n", strings.Join(downNodes, ","))
+		}
+		if len(downEdges) > 0 {
+			fmt.Fprintf(w, "linkStyle %s stroke:orangered\n", strings.Join(downEdges, ","))
+		}
+	}
+	return nil
+}
+
+func mermaidLabel(s string) string {
+	s = strings.ReplaceAll(s, `"`, "#quot;")
+	return strings.ReplaceAll(s, "\n", "<br/>")
+}
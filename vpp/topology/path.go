@@ -0,0 +1,189 @@
+package topology
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultMaxPathDepth is a default maximum number of hops of a path.
+const DefaultMaxPathDepth = 10
+
+// PathHop is a single hop of a path.
+type PathHop struct {
+	From  Endpoint
+	To    Endpoint
+	Type  string
+	State string
+}
+
+// IsDown returns true if the link of hop is down.
+func (h PathHop) IsDown() bool {
+	return strings.Contains(h.State, "down")
+}
+
+// Path is a sequence of hops between two endpoints.
+type Path struct {
+	Hops []PathHop
+}
+
+// IsDown returns true if any hop of path is down.
+func (p Path) IsDown() bool {
+	for _, hop := range p.Hops {
+		if hop.IsDown() {
+			return true
+		}
+	}
+	return false
+}
+
+// pathEdge is an undirected edge of topology graph.
+type pathEdge struct {
+	peer string
+	conn *Connection
+}
+
+// FindPaths returns all paths between endpoints selected by src and dst
+// with at most maxDepth hops. Selector has format "instance/interface"
+// where instance can be a suffix of instance ID. Connections are
+// traversed in both directions.
+func FindPaths(info *Info, src, dst string, maxDepth int) ([]Path, error) {
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxPathDepth
+	}
+
+	endpoints := map[string]Endpoint{}
+	edges := map[string][]pathEdge{}
+	seen := map[string]bool{}
+	for i := range info.Connections {
+		conn := &info.Connections[i]
+		a, b := endpointID(conn.Source), endpointID(conn.Destination)
+		if a == b {
+			continue
+		}
+		key := connKey(conn)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		endpoints[a] = conn.Source
+		endpoints[b] = conn.Destination
+		edges[a] = append(edges[a], pathEdge{peer: b, conn: conn})
+		edges[b] = append(edges[b], pathEdge{peer: a, conn: conn})
+	}
+
+	srcs := selectEndpoints(endpoints, src)
+	if len(srcs) == 0 {
+		return nil, fmt.Errorf("source endpoint %q not found", src)
+	}
+	dsts := selectEndpoints(endpoints, dst)
+	if len(dsts) == 0 {
+		return nil, fmt.Errorf("destination endpoint %q not found", dst)
+	}
+	isDst := map[string]bool{}
+	for _, id := range dsts {
+		isDst[id] = true
+	}
+
+	var paths []Path
+	visited := map[string]bool{}
+	var hops []PathHop
+
+	var walk func(id string)
+	walk = func(id string) {
+		if isDst[id] && len(hops) > 0 {
+			paths = append(paths, Path{Hops: append([]PathHop(nil), hops...)})
+			return
+		}
+		if len(hops) >= maxDepth {
+			return
+		}
+		visited[id] = true
+		for _, edge := range edges[id] {
+			if visited[edge.peer] {
+				continue
+			}
+			hops = append(hops, newPathHop(edge.conn, endpoints[id], endpoints[edge.peer]))
+			walk(edge.peer)
+			hops = hops[:len(hops)-1]
+		}
+		visited[id] = false
+	}
+	for _, id := range srcs {
+		walk(id)
+	}
+
+	sort.SliceStable(paths, func(i, j int) bool {
+		return len(paths[i].Hops) < len(paths[j].Hops)
+	})
+	return paths, nil
+}
+
+func newPathHop(conn *Connection, from, to Endpoint) PathHop {
+	state := conn.Metadata["state"]
+	for _, e := range []Endpoint{conn.Source, conn.Destination} {
+		if s := e.Metadata["state"]; strings.Contains(s, "down") {
+			state = s
+		}
+	}
+	if state == "" {
+		state = "up"
+	}
+	return PathHop{
+		From:  from,
+		To:    to,
+		Type:  conn.Metadata["type"],
+		State: state,
+	}
+}
+
+// selectEndpoints returns sorted IDs of endpoints matching selector.
+func selectEndpoints(endpoints map[string]Endpoint, selector string) []string {
+	var list []string
+	for id, e := range endpoints {
+		if e.Kind == FileEndpoint {
+			continue
+		}
+		name := endpointName(e)
+		if name == selector || strings.HasSuffix(name, "/"+selector) {
+			list = append(list, id)
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// endpointName returns name of endpoint in format "instance/interface".
+func endpointName(e Endpoint) string {
+	return fmt.Sprintf("%s/%s", strings.TrimPrefix(e.Instance, "instance::"), e.Interface)
+}
+
+// endpointID returns unique ID of endpoint used as node ID in graph.
+func endpointID(e Endpoint) string {
+	name := e.Interface
+	if e.Type == KernelNetwork {
+		name = fmt.Sprintf("LINUX-%s", name)
+	}
+	if e.Namespace != "" {
+		name += fmt.Sprintf("-NS-%s", e.Namespace)
+	}
+	return fmt.Sprintf("%v_%v", e.Instance, name)
+}
+
+// connKey returns key of connection that is the same for both directions.
+func connKey(conn *Connection) string {
+	return linkKey(conn.Source, conn.Destination)
+}
+
+// linkKey returns key of link between two endpoints.
+func linkKey(e1, e2 Endpoint) string {
+	return linkKeyIDs(endpointID(e1), endpointID(e2))
+}
+
+// linkKeyIDs returns key of link between two endpoint IDs.
+func linkKeyIDs(a, b string) string {
+	if a > b {
+		a, b = b, a
+	}
+	return a + "|" + b
+}
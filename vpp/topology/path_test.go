@@ -0,0 +1,73 @@
+package topology
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindPaths(t *testing.T) {
+	vppA := testNetwork("a")
+	vppB := testNetwork("b")
+	hostB := Network{Instance: "instance::b", Type: KernelNetwork, Namespace: "ns1"}
+
+	info := &Info{Connections: []Connection{
+		testConnection("memif-sock", testEndpoint(vppA, "memif0/0"), testEndpoint(vppB, "memif0/0")),
+		testConnection("memif-sock", testEndpoint(vppB, "memif0/0"), testEndpoint(vppA, "memif0/0")),
+		testConnection("l2xconn", testEndpoint(vppB, "tap0"), testEndpoint(vppB, "memif0/0")),
+		testConnection("tap-to-host", testEndpoint(vppB, "tap0"), testEndpoint(hostB, "eth0")),
+		testConnection("vxlan-tun", testEndpoint(vppA, "vxlan0"), testEndpoint(vppB, "vxlan0", "state", "down")),
+		testConnection("l2xconn", testEndpoint(vppA, "vxlan0"), testEndpoint(vppA, "memif0/0")),
+		testConnection("l2xconn", testEndpoint(vppB, "vxlan0"), testEndpoint(vppB, "tap0")),
+	}}
+
+	type hop struct {
+		From, To, Type, State string
+	}
+	pathHops := func(paths []Path) [][]hop {
+		var list [][]hop
+		for _, p := range paths {
+			var hops []hop
+			for _, h := range p.Hops {
+				hops = append(hops, hop{endpointName(h.From), endpointName(h.To), h.Type, h.State})
+			}
+			list = append(list, hops)
+		}
+		return list
+	}
+
+	paths, err := FindPaths(info, "a/memif0/0", "b/eth0", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]hop{
+		{
+			{"a/memif0/0", "b/memif0/0", "memif-sock", "up"},
+			{"b/memif0/0", "b/tap0", "l2xconn", "up"},
+			{"b/tap0", "b/eth0", "tap-to-host", "up"},
+		},
+		{
+			{"a/memif0/0", "a/vxlan0", "l2xconn", "up"},
+			{"a/vxlan0", "b/vxlan0", "vxlan-tun", "down"},
+			{"b/vxlan0", "b/tap0", "l2xconn", "up"},
+			{"b/tap0", "b/eth0", "tap-to-host", "up"},
+		},
+	}
+	if got := pathHops(paths); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindPaths()\n got: %+v\nwant: %+v", got, want)
+	}
+	if paths[0].IsDown() || !paths[1].IsDown() {
+		t.Errorf("expected only second path to be down")
+	}
+
+	paths, err = FindPaths(info, "a/memif0/0", "b/eth0", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 1 {
+		t.Errorf("expected 1 path with max depth 3, got %d", len(paths))
+	}
+
+	if _, err := FindPaths(info, "c/memif0/0", "b/eth0", 0); err == nil {
+		t.Errorf("expected error for unknown source")
+	}
+}
@@ -0,0 +1,58 @@
+package topology
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"go.ligato.io/vpp-probe/vpp"
+)
+
+// Snapshot is a topology saved at some point in time.
+type Snapshot struct {
+	Created time.Time
+	// Instances are IDs of instances the topology was built from.
+	Instances []string
+	Topology  *Info
+}
+
+// NewSnapshot returns snapshot of topology info built from instances.
+func NewSnapshot(instances []*vpp.Instance, info *Info) *Snapshot {
+	snapshot := &Snapshot{
+		Created:  time.Now(),
+		Topology: info,
+	}
+	for _, instance := range instances {
+		snapshot.Instances = append(snapshot.Instances, instance.ID())
+	}
+	return snapshot
+}
+
+// SaveSnapshot writes snapshot to file in JSON format.
+func SaveSnapshot(file string, snapshot *Snapshot) error {
+	b, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(file, b, 0644); err != nil {
+		return fmt.Errorf("writing snapshot failed: %w", err)
+	}
+	return nil
+}
+
+// LoadSnapshot reads snapshot from file.
+func LoadSnapshot(file string) (*Snapshot, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot failed: %w", err)
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(b, &snapshot); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", file, err)
+	}
+	if snapshot.Topology == nil {
+		snapshot.Topology = &Info{}
+	}
+	return &snapshot, nil
+}
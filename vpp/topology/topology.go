@@ -8,8 +8,10 @@ import (
 
 // Info groups together a topology information.
 type Info struct {
-	Networks    []Network
+	Networks    []NetworkInfo
 	Connections []Connection
+	// Unpaired are endpoints whose peer was not found.
+	Unpaired []Endpoint `json:",omitempty"`
 }
 
 // NetworkType is a type of network found in a topology.
@@ -34,6 +36,27 @@ type Network struct {
 	Namespace string
 }
 
+// NetworkInfo is a network with endpoints attached to it.
+type NetworkInfo struct {
+	Network
+	// Cluster is the Kubernetes cluster of instance.
+	Cluster string `json:",omitempty"`
+	// Node is the Kubernetes node of instance.
+	Node      string `json:",omitempty"`
+	Endpoints []Endpoint
+}
+
+// Label returns label of network used in outputs.
+func (n NetworkInfo) Label() string {
+	if n.Type == UserNetwork {
+		return "VPP"
+	}
+	if n.Namespace == "" {
+		return "host"
+	}
+	return fmt.Sprintf("NS: %s", n.Namespace)
+}
+
 type EndpointType string
 
 const (
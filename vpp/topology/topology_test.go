@@ -0,0 +1,26 @@
+package topology
+
+// testNetwork returns VPP network of instance with id.
+func testNetwork(id string) Network {
+	return Network{Instance: "instance::" + id, Type: UserNetwork}
+}
+
+// testEndpoint returns interface endpoint in network with metadata given
+// as key and value pairs.
+func testEndpoint(network Network, iface string, metadata ...string) Endpoint {
+	e := Endpoint{Network: network, Interface: iface, Kind: InterfaceEndpoint}
+	for i := 0; i+1 < len(metadata); i += 2 {
+		e.addMetadata(metadata[i], metadata[i+1])
+	}
+	return e
+}
+
+// testConnection returns connection of type typ from src to dst with
+// metadata given as key and value pairs.
+func testConnection(typ string, src, dst Endpoint, metadata ...string) Connection {
+	c := Connection{Source: src, Destination: dst, Metadata: map[string]string{"type": typ}}
+	for i := 0; i+1 < len(metadata); i += 2 {
+		c.addMetadata(metadata[i], metadata[i+1])
+	}
+	return c
+}
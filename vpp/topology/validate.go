@@ -0,0 +1,218 @@
+package topology
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Severity is a severity of validation finding.
+type Severity string
+
+const (
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Rule is a validation rule that produced a finding.
+type Rule string
+
+const (
+	// RuleDanglingSocket is a memif or vhost-user socket with no peer.
+	RuleDanglingSocket Rule = "dangling-socket"
+	// RuleDanglingVeth is a veth with missing partner.
+	RuleDanglingVeth Rule = "dangling-veth"
+	// RuleMTUMismatch is a connection with different MTU on each side.
+	RuleMTUMismatch Rule = "mtu-mismatch"
+	// RuleStateMismatch is a connection admin up on one side and down on the other.
+	RuleStateMismatch Rule = "state-mismatch"
+	// RuleTunnelMismatch is a tunnel with asymmetric VNI or addresses.
+	RuleTunnelMismatch Rule = "tunnel-mismatch"
+	// RuleTunnelNoPeer is a tunnel with no other end found.
+	RuleTunnelNoPeer Rule = "tunnel-no-peer"
+)
+
+// Finding is a problem found in topology.
+type Finding struct {
+	Severity Severity
+	Rule     Rule
+	// Subject is an endpoint or connection the finding refers to.
+	Subject string
+	Message string
+}
+
+// HasErrors returns true if any of findings is an error.
+func HasErrors(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// linkConnTypes are connections between two sides of the same link
+// which should have equal MTU.
+var linkConnTypes = map[string]bool{
+	"memif-sock":       true,
+	"vhost-sock":       true,
+	"veth-pair":        true,
+	"tap-to-host":      true,
+	"host-to-tap":      true,
+	"afpacket-to-host": true,
+	"host-to-afpacket": true,
+}
+
+// localConnTypes are relations inside single instance, not links.
+var localConnTypes = map[string]bool{
+	"l2xconn":     true,
+	"bond-member": true,
+	"sub-parent":  true,
+}
+
+// Validate checks topology info for dangling peers, MTU and state
+// mismatches and tunnel asymmetry. Errors are sorted first.
+func Validate(info *Info) []Finding {
+	var findings []Finding
+	seen := map[string]bool{}
+	add := func(key string, f Finding) {
+		key = string(f.Rule) + "|" + key
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		findings = append(findings, f)
+	}
+
+	for _, conn := range info.Connections {
+		typ := conn.Metadata["type"]
+		src, dst := conn.Source, conn.Destination
+		if src.Kind == FileEndpoint || dst.Kind == FileEndpoint || localConnTypes[typ] {
+			continue
+		}
+		if endpointID(src) > endpointID(dst) {
+			src, dst = dst, src
+		}
+		key := linkKey(src, dst)
+		subject := fmt.Sprintf("%s <-> %s", describeEndpoint(src), describeEndpoint(dst))
+
+		if mtu1, mtu2 := src.Metadata["mtu"], dst.Metadata["mtu"]; linkConnTypes[typ] && mtu1 != "" && mtu2 != "" && mtu1 != mtu2 {
+			add(key, Finding{
+				Severity: SeverityError,
+				Rule:     RuleMTUMismatch,
+				Subject:  subject,
+				Message:  fmt.Sprintf("%s MTU %s differs from MTU %s", typ, mtu1, mtu2),
+			})
+		}
+		if s1, s2 := src.Metadata["state"], dst.Metadata["state"]; s1 != "" && s2 != "" && (s1 == "down") != (s2 == "down") {
+			up, down := src, dst
+			if s1 == "down" {
+				up, down = dst, src
+			}
+			add(key, Finding{
+				Severity: SeverityError,
+				Rule:     RuleStateMismatch,
+				Subject:  subject,
+				Message:  fmt.Sprintf("%s is admin up on %s but admin down on %s", typ, describeEndpoint(up), describeEndpoint(down)),
+			})
+		}
+	}
+
+	for i, e := range info.Unpaired {
+		typ := e.Metadata["type"]
+		subject := describeEndpoint(e)
+		switch typ {
+		case "memif-sock", "vhost-sock":
+			add(endpointID(e), Finding{
+				Severity: SeverityWarning,
+				Rule:     RuleDanglingSocket,
+				Subject:  subject,
+				Message:  fmt.Sprintf("no peer connected to socket %s", e.Metadata["peer"]),
+			})
+		case "veth-pair":
+			add(endpointID(e), Finding{
+				Severity: SeverityError,
+				Rule:     RuleDanglingVeth,
+				Subject:  subject,
+				Message:  fmt.Sprintf("veth partner %s not found", e.Metadata["peer"]),
+			})
+		default:
+			if _, ok := e.Metadata["tunnel-dst"]; ok {
+				validateTunnel(info.Unpaired, i, add)
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return findings[i].Severity == SeverityError
+		}
+		if findings[i].Subject != findings[j].Subject {
+			return findings[i].Subject < findings[j].Subject
+		}
+		return findings[i].Rule < findings[j].Rule
+	})
+	return findings
+}
+
+// validateTunnel looks for the other end of unpaired tunnel among other
+// unpaired tunnels and reports VNI or address asymmetry.
+func validateTunnel(unpaired []Endpoint, idx int, add func(string, Finding)) {
+	tun := unpaired[idx]
+	typ := tun.Metadata["type"]
+	src, dst, vni := tun.Metadata["tunnel-src"], tun.Metadata["tunnel-dst"], tun.Metadata["vni"]
+
+	var found bool
+	for i, tun2 := range unpaired {
+		if i == idx || tun2.Metadata["type"] != typ {
+			continue
+		}
+		src2, dst2, vni2 := tun2.Metadata["tunnel-src"], tun2.Metadata["tunnel-dst"], tun2.Metadata["vni"]
+		if dst != src2 && src != dst2 {
+			continue
+		}
+		found = true
+
+		a, b := tun, tun2
+		if endpointID(a) > endpointID(b) {
+			a, b = b, a
+		}
+		subject := fmt.Sprintf("%s <-> %s", describeEndpoint(a), describeEndpoint(b))
+
+		var msg string
+		if src != dst2 || dst != src2 {
+			msg = fmt.Sprintf("%s %s -> %s is not reverse of %s -> %s", typ, src, dst, src2, dst2)
+		} else if vni != vni2 {
+			msg = fmt.Sprintf("%s VNI %s differs from VNI %s", typ, vni, vni2)
+		} else {
+			msg = fmt.Sprintf("%s %s -> %s parameters do not match", typ, src, dst)
+		}
+		add(linkKey(a, b), Finding{
+			Severity: SeverityError,
+			Rule:     RuleTunnelMismatch,
+			Subject:  subject,
+			Message:  msg,
+		})
+	}
+
+	if !found {
+		add(endpointID(tun), Finding{
+			Severity: SeverityWarning,
+			Rule:     RuleTunnelNoPeer,
+			Subject:  describeEndpoint(tun),
+			Message:  fmt.Sprintf("no %s endpoint found for %s -> %s (VNI %s)", typ, src, dst, vni),
+		})
+	}
+}
+
+// describeEndpoint returns endpoint name used in findings.
+func describeEndpoint(e Endpoint) string {
+	name := endpointName(e)
+	if e.Type == KernelNetwork {
+		name += " (linux"
+		if e.Namespace != "" {
+			name += " ns " + e.Namespace
+		}
+		name += ")"
+	}
+	return name
+}
@@ -0,0 +1,78 @@
+package topology
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	a, b, c := testNetwork("a"), testNetwork("b"), testNetwork("c")
+
+	tests := []struct {
+		name string
+		info *Info
+		want []Finding
+	}{
+		{
+			name: "consistent",
+			info: &Info{Connections: []Connection{
+				testConnection("memif-sock", testEndpoint(a, "memif1", "state", "up", "mtu", "1500"), testEndpoint(b, "memif1", "state", "link down", "mtu", "1500")),
+			}},
+			want: nil,
+		},
+		{
+			name: "mtu and state mismatch reported once per link",
+			info: &Info{Connections: []Connection{
+				testConnection("memif-sock", testEndpoint(a, "memif1", "state", "up", "mtu", "1500"), testEndpoint(b, "memif1", "state", "down", "mtu", "9000")),
+				testConnection("memif-sock", testEndpoint(b, "memif1", "state", "down", "mtu", "9000"), testEndpoint(a, "memif1", "state", "up", "mtu", "1500")),
+				testConnection("sub-parent", testEndpoint(a, "sub1", "state", "up"), testEndpoint(a, "memif1", "state", "down")),
+			}},
+			want: []Finding{
+				{SeverityError, RuleMTUMismatch, "a/memif1 <-> b/memif1", "memif-sock MTU 1500 differs from MTU 9000"},
+				{SeverityError, RuleStateMismatch, "a/memif1 <-> b/memif1", "memif-sock is admin up on a/memif1 but admin down on b/memif1"},
+			},
+		},
+		{
+			name: "dangling peers",
+			info: &Info{Unpaired: []Endpoint{
+				testEndpoint(a, "memif2", "type", "memif-sock", "peer", "/run/memif.sock"),
+				{
+					Network:   Network{Instance: "instance::a", Type: KernelNetwork, Namespace: "app"},
+					Interface: "veth1",
+					Kind:      InterfaceEndpoint,
+					Metadata:  map[string]string{"type": "veth-pair", "peer": "veth2"},
+				},
+			}},
+			want: []Finding{
+				{SeverityError, RuleDanglingVeth, "a/veth1 (linux ns app)", "veth partner veth2 not found"},
+				{SeverityWarning, RuleDanglingSocket, "a/memif2", "no peer connected to socket /run/memif.sock"},
+			},
+		},
+		{
+			name: "vxlan asymmetry",
+			info: &Info{Unpaired: []Endpoint{
+				testEndpoint(a, "vxlan0", "type", "vxlan-tun", "tunnel-src", "10.0.0.1", "tunnel-dst", "10.0.0.2", "vni", "10"),
+				testEndpoint(b, "vxlan0", "type", "vxlan-tun", "tunnel-src", "10.0.0.2", "tunnel-dst", "10.0.0.1", "vni", "11"),
+				testEndpoint(a, "vxlan1", "type", "vxlan-tun", "tunnel-src", "10.0.1.1", "tunnel-dst", "10.0.1.2", "vni", "20"),
+				testEndpoint(c, "vxlan0", "type", "vxlan-tun", "tunnel-src", "10.0.1.2", "tunnel-dst", "10.0.1.3", "vni", "20"),
+				testEndpoint(a, "vxlan2", "type", "vxlan-tun", "tunnel-src", "10.0.2.1", "tunnel-dst", "10.0.2.2", "vni", "30"),
+			}},
+			want: []Finding{
+				{SeverityError, RuleTunnelMismatch, "a/vxlan0 <-> b/vxlan0", "vxlan-tun VNI 10 differs from VNI 11"},
+				{SeverityError, RuleTunnelMismatch, "a/vxlan1 <-> c/vxlan0", "vxlan-tun 10.0.1.1 -> 10.0.1.2 is not reverse of 10.0.1.2 -> 10.0.1.3"},
+				{SeverityWarning, RuleTunnelNoPeer, "a/vxlan2", "no vxlan-tun endpoint found for 10.0.2.1 -> 10.0.2.2 (VNI 30)"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Validate(tt.info)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate()\n got: %+v\nwant: %+v", got, tt.want)
+			}
+			if HasErrors(got) != HasErrors(tt.want) {
+				t.Errorf("HasErrors() = %v", HasErrors(got))
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package vpp
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"go.ligato.io/vpp-agent/v3/plugins/kvscheduler/api"
+
+	"go.ligato.io/vpp-probe/vpp/agent"
+	vppapi "go.ligato.io/vpp-probe/vpp/api"
+)
+
+// MismatchField is a field of interface that differs between agent config
+// and VPP runtime state.
+type MismatchField string
+
+const (
+	MismatchAdminState MismatchField = "admin-state"
+	MismatchIP         MismatchField = "ip"
+	MismatchVRF        MismatchField = "vrf"
+	MismatchMTU        MismatchField = "mtu"
+	MismatchMAC        MismatchField = "mac"
+	// MismatchMissing is an interface configured by agent not found in VPP.
+	MismatchMissing MismatchField = "missing"
+	// MismatchUnmanaged is an interface in VPP not configured by agent.
+	MismatchUnmanaged MismatchField = "unmanaged"
+)
+
+// ConfigMismatch is a difference between agent config and VPP runtime state.
+type ConfigMismatch struct {
+	// Interface is a name of interface in agent config (if managed).
+	Interface string `json:",omitempty"`
+	// VppName is an internal name of interface in VPP (if found).
+	VppName  string `json:",omitempty"`
+	Field    MismatchField
+	Expected string `json:",omitempty"`
+	Actual   string `json:",omitempty"`
+}
+
+// VerifyInterfaces compares VPP interfaces configured by agent (from NB)
+// with interfaces dumped from VPP. Interfaces are matched by sw_if_index
+// from agent metadata, internal name or tag. It returns mismatches of
+// admin state, IP addresses, VRF, MTU and MAC, interfaces missing in VPP
+// and VPP interfaces not managed by agent.
+func VerifyInterfaces(configured []agent.VppInterface, ifaces []*vppapi.Interface) []ConfigMismatch {
+	byIndex := map[uint32]*vppapi.Interface{}
+	byName := map[string]*vppapi.Interface{}
+	byTag := map[string]*vppapi.Interface{}
+	for _, iface := range ifaces {
+		byIndex[iface.Index] = iface
+		byName[iface.Name] = iface
+		if iface.Tag != "" {
+			byTag[iface.Tag] = iface
+		}
+	}
+
+	var mismatches []ConfigMismatch
+	managed := map[uint32]bool{}
+
+	for _, c := range configured {
+		if c.Value == nil {
+			continue
+		}
+		// values obtained from SB are not managed by agent
+		if api.ValueOrigin(c.Origin) != api.FromNB {
+			continue
+		}
+		iface := matchInterface(c, byIndex, byName, byTag)
+		if iface == nil {
+			mismatches = append(mismatches, ConfigMismatch{
+				Interface: c.Value.GetName(),
+				Field:     MismatchMissing,
+			})
+			continue
+		}
+		managed[iface.Index] = true
+		mismatches = append(mismatches, compareInterface(c, iface)...)
+	}
+
+	for _, iface := range ifaces {
+		if iface.Index == 0 || managed[iface.Index] {
+			continue
+		}
+		mismatches = append(mismatches, ConfigMismatch{
+			VppName: iface.Name,
+			Field:   MismatchUnmanaged,
+		})
+	}
+
+	sort.SliceStable(mismatches, func(i, j int) bool {
+		a, b := mismatches[i], mismatches[j]
+		// unmanaged interfaces last
+		if (a.Interface == "") != (b.Interface == "") {
+			return a.Interface != ""
+		}
+		if a.Interface != b.Interface {
+			return a.Interface < b.Interface
+		}
+		return a.VppName < b.VppName
+	})
+	return mismatches
+}
+
+func matchInterface(c agent.VppInterface, byIndex map[uint32]*vppapi.Interface, byName, byTag map[string]*vppapi.Interface) *vppapi.Interface {
+	if _, ok := c.Metadata["SwIfIndex"]; ok {
+		if iface, ok := byIndex[uint32(c.Index())]; ok {
+			return iface
+		}
+	}
+	if name, ok := c.Metadata["InternalName"].(string); ok && name != "" {
+		if iface, ok := byName[name]; ok {
+			return iface
+		}
+	}
+	return byTag[c.Value.GetName()]
+}
+
+func compareInterface(c agent.VppInterface, iface *vppapi.Interface) []ConfigMismatch {
+	var mismatches []ConfigMismatch
+	add := func(field MismatchField, expected, actual string) {
+		mismatches = append(mismatches, ConfigMismatch{
+			Interface: c.Value.GetName(),
+			VppName:   iface.Name,
+			Field:     field,
+			Expected:  expected,
+			Actual:    actual,
+		})
+	}
+
+	if c.Value.GetEnabled() != iface.Status.Up {
+		add(MismatchAdminState, adminState(c.Value.GetEnabled()), adminState(iface.Status.Up))
+	}
+
+	// addresses are assigned dynamically with DHCP or borrowed by unnumbered
+	if !c.Value.GetSetDhcpClient() && c.Value.GetUnnumbered() == nil {
+		expected := normalizeIPs(c.Value.GetIpAddresses())
+		actual := normalizeIPs(iface.IPs)
+		if strings.Join(expected, ",") != strings.Join(actual, ",") {
+			add(MismatchIP, strings.Join(expected, ", "), strings.Join(actual, ", "))
+		}
+	}
+
+	if vrf := uint(c.Value.GetVrf()); vrf != iface.VRF.IP4 {
+		add(MismatchVRF, fmt.Sprint(vrf), fmt.Sprint(iface.VRF.IP4))
+	} else if hasIPv6(c.Value.GetIpAddresses()) && vrf != iface.VRF.IP6 {
+		add(MismatchVRF, fmt.Sprintf("%d (ip6)", vrf), fmt.Sprintf("%d (ip6)", iface.VRF.IP6))
+	}
+
+	// agent may set either link or L3 MTU depending on interface type
+	if mtu := uint(c.Value.GetMtu()); mtu != 0 && mtu != iface.MTUs.L3 && mtu != iface.MTUs.Link {
+		add(MismatchMTU, fmt.Sprint(mtu), fmt.Sprint(iface.MTUs.L3))
+	}
+
+	if mac := c.Value.GetPhysAddress(); mac != "" && !strings.EqualFold(mac, iface.MAC) {
+		add(MismatchMAC, strings.ToLower(mac), strings.ToLower(iface.MAC))
+	}
+
+	return mismatches
+}
+
+func adminState(up bool) string {
+	if up {
+		return "up"
+	}
+	return "down"
+}
+
+// normalizeIPs returns sorted addresses in canonical form without
+// IPv6 link-local addresses which are assigned automatically.
+func normalizeIPs(ips []string) []string {
+	list := make([]string, 0, len(ips))
+	for _, s := range ips {
+		ip, ipnet, err := net.ParseCIDR(s)
+		if err != nil {
+			list = append(list, s)
+			continue
+		}
+		if ip.To4() == nil && ip.IsLinkLocalUnicast() {
+			continue
+		}
+		ones, _ := ipnet.Mask.Size()
+		list = append(list, fmt.Sprintf("%s/%d", ip, ones))
+	}
+	sort.Strings(list)
+	return list
+}
+
+func hasIPv6(ips []string) bool {
+	for _, s := range ips {
+		if ip, _, err := net.ParseCIDR(s); err == nil && ip.To4() == nil {
+			return true
+		}
+	}
+	return false
+}
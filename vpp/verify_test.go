@@ -0,0 +1,77 @@
+package vpp
+
+import (
+	"reflect"
+	"testing"
+
+	"go.ligato.io/vpp-agent/v3/plugins/kvscheduler/api"
+	vpp_interfaces "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/interfaces"
+
+	"go.ligato.io/vpp-probe/vpp/agent"
+	vppapi "go.ligato.io/vpp-probe/vpp/api"
+)
+
+func TestVerifyInterfaces(t *testing.T) {
+	configured := []agent.VppInterface{
+		{
+			KVData: agent.KVData{
+				Metadata: map[string]interface{}{"SwIfIndex": 1, "InternalName": "tap0"},
+				Origin:   agent.ValueOrigin(api.FromNB),
+			},
+			Value: &vpp_interfaces.Interface{
+				Name:        "tap-1",
+				Enabled:     true,
+				IpAddresses: []string{"10.0.0.1/24"},
+				Mtu:         9000,
+				PhysAddress: "02:00:00:00:00:01",
+			},
+		},
+		{
+			KVData: agent.KVData{
+				Metadata: map[string]interface{}{"SwIfIndex": 2, "InternalName": "memif0/0"},
+				Origin:   agent.ValueOrigin(api.FromNB),
+			},
+			Value: &vpp_interfaces.Interface{
+				Name:    "memif-1",
+				Enabled: true,
+				Vrf:     10,
+			},
+		},
+		{
+			KVData: agent.KVData{
+				Origin: agent.ValueOrigin(api.FromNB),
+			},
+			Value: &vpp_interfaces.Interface{
+				Name: "loop-1",
+			},
+		},
+	}
+	ifaces := []*vppapi.Interface{
+		{Index: 0, Name: "local0"},
+		{
+			Index:  1,
+			Name:   "tap0",
+			Status: vppapi.Status{Up: true},
+			IPs:    []string{"10.0.0.1/24", "fe80::1/64"},
+			MTUs:   vppapi.MTU{L3: 9000},
+			MAC:    "02:00:00:00:00:01",
+		},
+		{
+			Index: 2,
+			Name:  "memif0/0",
+			VRF:   vppapi.VRF{IP4: 0},
+		},
+		{Index: 3, Name: "host-eth0"},
+	}
+
+	want := []ConfigMismatch{
+		{Interface: "loop-1", Field: MismatchMissing},
+		{Interface: "memif-1", VppName: "memif0/0", Field: MismatchAdminState, Expected: "up", Actual: "down"},
+		{Interface: "memif-1", VppName: "memif0/0", Field: MismatchVRF, Expected: "10", Actual: "0"},
+		{VppName: "host-eth0", Field: MismatchUnmanaged},
+	}
+	got := VerifyInterfaces(configured, ifaces)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("VerifyInterfaces()\n got: %+v\nwant: %+v", got, want)
+	}
+}
@@ -0,0 +1,36 @@
+package vpp
+
+// DefaultSkewThreshold is a share of total above which a single worker
+// thread is considered skewed.
+const DefaultSkewThreshold = 0.8
+
+// WorkerSkew returns index of the worker thread with the largest share of the
+// total of values and the share. Values are indexed by thread, the main
+// thread (index 0) is ignored and share is zero unless there are at least
+// two worker threads with non-zero total.
+func WorkerSkew(values []float64) (worker int, share float64) {
+	if len(values) < 3 {
+		return 0, 0
+	}
+	var total, max float64
+	for i, v := range values[1:] {
+		total += v
+		if v > max {
+			max = v
+			worker = i + 1
+		}
+	}
+	if total <= 0 {
+		return 0, 0
+	}
+	return worker, max / total
+}
+
+// Uint64sToFloats converts counter values to floats.
+func Uint64sToFloats(values []uint64) []float64 {
+	floats := make([]float64, len(values))
+	for i, v := range values {
+		floats[i] = float64(v)
+	}
+	return floats
+}
@@ -0,0 +1,26 @@
+package vpp
+
+import "testing"
+
+func TestWorkerSkew(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []float64
+		wantWorker int
+		wantShare  float64
+	}{
+		{name: "main only", values: []float64{10}},
+		{name: "single worker", values: []float64{1, 10}},
+		{name: "no traffic", values: []float64{5, 0, 0}},
+		{name: "balanced", values: []float64{0, 10, 10}, wantWorker: 1, wantShare: 0.5},
+		{name: "skewed", values: []float64{100, 1, 2, 0, 97}, wantWorker: 4, wantShare: 0.97},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			worker, share := WorkerSkew(tt.values)
+			if worker != tt.wantWorker || share != tt.wantShare {
+				t.Errorf("WorkerSkew() = %v, %v, want %v, %v", worker, share, tt.wantWorker, tt.wantShare)
+			}
+		})
+	}
+}